@@ -2,10 +2,13 @@ package mipix
 
 import (
 	"image"
+	"image/color"
 
+	ebimath "github.com/edwinsyarief/ebi-math"
 	"github.com/edwinsyarief/mipix/shaker"
 	"github.com/edwinsyarief/mipix/tracker"
 	"github.com/edwinsyarief/mipix/zoomer"
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // See [Camera]().
@@ -36,6 +39,14 @@ func (AccessorCamera) SetTracker(tracker tracker.Tracker) {
 	pkgController.cameraSetTracker(tracker)
 }
 
+// Like [AccessorCamera.SetTracker](), but if the new tracker implements
+// [tracker.Seeder], it's seeded with the current camera speed (see
+// [AccessorCamera.Speed]()). This avoids the visible discontinuity
+// that swapping trackers mid-motion would otherwise cause.
+func (AccessorCamera) SetTrackerSmooth(tracker tracker.Tracker) {
+	pkgController.cameraSetTrackerSmooth(tracker)
+}
+
 // Feeds the camera the newest target coordinates to point to or
 // look at. The time that it takes to reach these new coordinates
 // will depend on the behavior of the current [tracker.Tracker].
@@ -46,6 +57,86 @@ func (AccessorCamera) NotifyCoordinates(x, y float64) {
 	pkgController.cameraNotifyCoordinates(x, y)
 }
 
+// Similar to [AccessorCamera.NotifyCoordinates](), but the given
+// values are added to the most recently notified target coordinates
+// instead of replacing them. Commonly used for relative camera nudges,
+// free-look and drag-panning.
+func (AccessorCamera) NotifyDelta(dx, dy float64) {
+	pkgController.cameraNotifyDelta(dx, dy)
+}
+
+// Advances the tracking target in the given direction, at the given
+// speed in logical pixels per second. Meant to be called once per
+// update with joystick input, e.g. for twin-stick free-look.
+//
+// Directions longer than 1 are normalized, while shorter ones are
+// preserved, so analog sticks can pan at partial speeds. The movement
+// is applied through [AccessorCamera.NotifyCoordinates](), so the
+// tracker still smooths it out. With [AccessorCamera.SetBounds](),
+// the target is also kept within the bounds.
+func (AccessorCamera) Pan(dirX, dirY, speedPerSecond float64) {
+	pkgController.cameraPan(dirX, dirY, speedPerSecond)
+}
+
+// Sweeps the camera to the given coordinates over the given duration,
+// following a scripted path that overrides the current tracker. With
+// a non-zero anticipation, the camera first pulls back in the opposite
+// direction (anticipation is the wind-up distance relative to the total
+// travel distance, so 0.1 means 10%) before sweeping towards the target.
+// Useful for cinematic pans like boss introductions.
+//
+// The sweep destination also becomes the new target coordinates, and
+// when the sweep is done the regular tracker resumes. Calling
+// [AccessorCamera.NotifyCoordinates]() during the sweep doesn't
+// interrupt it, but the tracker will move towards the newly notified
+// coordinates once the sweep ends. See also [AccessorCamera.OnArrive]().
+func (AccessorCamera) SweepTo(x, y float64, anticipation float64, duration TicksDuration) {
+	pkgController.cameraSweepTo(x, y, anticipation, duration)
+}
+
+// Returns whether a [AccessorCamera.SweepTo]() is in progress.
+func (AccessorCamera) IsSweeping() bool {
+	return pkgController.cameraIsSweeping()
+}
+
+// Sets a callback to be invoked when a [AccessorCamera.SweepTo]()
+// reaches its destination. The callback is invoked during the
+// camera coordinates update. Passing nil removes the callback.
+func (AccessorCamera) OnArrive(callback func()) {
+	pkgController.cameraOnArrive(callback)
+}
+
+// See [AccessorCamera.SetScrollWindow]().
+type ScrollMode uint8
+
+const (
+	ScrollDisabled      ScrollMode = iota // default, camera follows the target directly
+	ScrollWindow                          // horizontal scrolling only when crossing the thresholds
+	ScrollWindowForward                   // like ScrollWindow, but never scrolling back to the left
+
+	scrollModeEndSentinel
+)
+
+// Enables classic "window scrolling" for the horizontal axis, as
+// used in many retro platformers. With window scrolling, the camera
+// doesn't move while the notified target coordinates remain within
+// the thresholds, and it only scrolls when the target crosses them.
+// With [ScrollWindowForward], the camera never scrolls back.
+//
+// Thresholds are relative to the visible area width, in [0, 1]
+// range. For example, with thresholds 0.3 and 0.5, the camera will
+// start scrolling right when the target passes the middle of the
+// screen. The vertical axis is not affected.
+//
+// The scroll window modifies the effective target before the
+// current [tracker.Tracker] runs, so the two can be combined.
+// [AccessorCamera.ResetCoordinates]() also resets the window.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCamera) SetScrollWindow(mode ScrollMode, leftThreshold, rightThreshold float64) {
+	pkgController.cameraSetScrollWindow(mode, leftThreshold, rightThreshold)
+}
+
 // Immediately sets the camera coordinates to the given values.
 // Commonly used when changing scenes or maps.
 func (AccessorCamera) ResetCoordinates(x, y float64) {
@@ -72,6 +163,18 @@ func (AccessorCamera) FlushCoordinates() {
 	pkgController.cameraFlushCoordinates()
 }
 
+// Commits the camera state immediately: the notified coordinates
+// and target zoom are applied without transitions, and the camera
+// area is updated, so [AccessorCamera.Area]() is valid right away.
+//
+// This is mostly useful during initialization, before [Run](), to
+// prevent the first frame from being drawn with a camera area that
+// doesn't match the initial setup. Requires the game resolution to
+// be set.
+func (AccessorCamera) Prime() {
+	pkgController.cameraPrime()
+}
+
 // Returns the logical area of the game that has to be
 // rendered on [Game].Draw()'s canvas or successive logical
 // draws. Notice that this can change after each [Game].Update(),
@@ -84,6 +187,31 @@ func (AccessorCamera) Area() image.Rectangle {
 	return pkgController.cameraAreaGet()
 }
 
+// Returns the camera area as it was before the last coordinates
+// flush, typically the area of the previous tick. Useful for
+// delta-based effects like motion smears that depend on how
+// much the camera moved since the last frame.
+//
+// See also [AccessorCamera.Area]().
+func (AccessorCamera) PreviousArea() image.Rectangle {
+	return pkgController.cameraPreviousArea()
+}
+
+// Registers a handler to be invoked whenever the integer camera
+// area changes. Sub-pixel camera motion that doesn't change the
+// integer area doesn't trigger the handler, which makes it cheap
+// and a good fit for loading and unloading world chunks.
+//
+// The handler is typically invoked during the camera update, which
+// happens right after [Game].Update() or on [AccessorCamera.FlushCoordinates](),
+// but it can also be invoked from setters that change the area
+// immediately, like [AccessorCamera.ResetCoordinates]() or
+// [SetResolution](). Only one handler can be registered, and
+// passing nil unregisters it.
+func (AccessorCamera) OnAreaChanged(handler func(old, new image.Rectangle)) {
+	pkgController.cameraOnAreaChanged(handler)
+}
+
 // Similar to [AccessorCamera.Area](), but without rounding up
 // the coordinates and returning the exact values. Rarely
 // necessary in practice.
@@ -91,6 +219,147 @@ func (AccessorCamera) AreaF64() (minX, minY, maxX, maxY float64) {
 	return pkgController.cameraAreaF64()
 }
 
+// Returns the visible world area, like [AccessorCamera.AreaF64](),
+// but expanded by the given margin on each side. This is the exact
+// primitive needed for entity culling loops, where entities slightly
+// offscreen should often keep being simulated.
+//
+// Negative margins shrink the area instead.
+func (AccessorCamera) VisibleWorldBounds(margin float64) (minX, minY, maxX, maxY float64) {
+	return pkgController.cameraVisibleWorldBounds(margin)
+}
+
+// With the hybrid pixel mode enabled, the camera translation is
+// snapped to whole logical pixels, while zoom remains fractional
+// and smoothly projected at high resolution. This gives classic
+// crisp scrolling without giving up on smooth zoom transitions.
+//
+// Unlike [AccessorScaling.SetTightCanvas](), which snaps the canvas
+// corner and can make zoom transitions jittery, this snaps the camera
+// focus instead. Defaults to false.
+func (AccessorCamera) SetHybridPixelMode(enabled bool) {
+	pkgController.cameraSetHybridPixelMode(enabled)
+}
+
+// Returns whether the hybrid pixel mode is enabled.
+// See [AccessorCamera.SetHybridPixelMode]() for more details.
+func (AccessorCamera) IsHybridPixelMode() bool {
+	return pkgController.cameraIsHybridPixelMode()
+}
+
+// Makes the camera automatically follow one of multiple targets.
+// On each camera update, the points function is invoked, and the
+// point chosen by the selector becomes the new tracking target, as
+// if [AccessorCamera.NotifyCoordinates]() had been called with it.
+// If the points function returns no points, the target is preserved.
+//
+// If the selector is nil, the point nearest to the current camera
+// position is selected. Passing a nil points function disables the
+// automatic follow. Typical use-cases include combat cameras that
+// lock onto the closest enemy.
+func (AccessorCamera) SetFollowTargets(points func() []ebimath.Vector, selector func(points []ebimath.Vector) ebimath.Vector) {
+	pkgController.cameraSetFollowTargets(points, selector)
+}
+
+// Sets world bounds for the camera. The camera area is clamped after
+// tracking, shakes and zoom are applied, so the visible area never
+// shows anything outside the bounds. On axes where the bounds are
+// smaller than the visible area, the camera is centered on the bounds
+// instead. Both [AccessorCamera.Area]() and [AccessorCamera.AreaF64]()
+// reflect the clamping.
+//
+// Notice that only the visible area is clamped, the tracker position
+// is not modified. For more complex containment rules, see
+// [AccessorCamera.SetConstraint]().
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCamera) SetBounds(minX, minY, maxX, maxY float64) {
+	pkgController.cameraSetBounds(minX, minY, maxX, maxY)
+}
+
+// Removes the camera bounds set with [AccessorCamera.SetBounds]().
+func (AccessorCamera) ClearBounds() {
+	pkgController.cameraClearBounds()
+}
+
+// Returns the camera bounds set with [AccessorCamera.SetBounds](),
+// or ok = false if no bounds are set.
+func (AccessorCamera) GetBounds() (minX, minY, maxX, maxY float64, ok bool) {
+	return pkgController.cameraGetBounds()
+}
+
+// Sets a function to constrain the camera focus to valid positions.
+// The constraint is invoked on each camera update, after the tracker
+// has moved the camera, and it must return the position the camera
+// focus should be moved to. This allows implementing any containment
+// rule, like irregular regions or hex map borders, while mipix
+// still handles the smoothing. Shakes are applied after the constraint.
+//
+// Passing nil removes the constraint.
+func (AccessorCamera) SetConstraint(constraint func(x, y float64) (float64, float64)) {
+	pkgController.cameraSetConstraint(constraint)
+}
+
+// Enables or disables camera tracking. While disabled, the camera
+// stops following the notified coordinates, but zoom and shakes keep
+// updating as usual. When re-enabled, the camera resumes following
+// the target from its current position. Tracking is enabled by default.
+//
+// Unlike setting a [tracker.Frozen] tracker, this doesn't require
+// swapping trackers, so stateful trackers are preserved.
+func (AccessorCamera) SetTrackingEnabled(enabled bool) {
+	pkgController.cameraSetTrackingEnabled(enabled)
+}
+
+// Returns whether camera tracking is enabled.
+// See [AccessorCamera.SetTrackingEnabled]() for more details.
+func (AccessorCamera) IsTrackingEnabled() bool {
+	return pkgController.cameraIsTrackingEnabled()
+}
+
+// Returns the camera speed caused by the tracker during the last
+// update, in logical units per second. Shakes are not included.
+// Useful to drive speed-reactive effects like motion blur intensity
+// or wind audio, without having to estimate the velocity from noisy
+// position deltas.
+func (AccessorCamera) Speed() (vx, vy float64) {
+	return pkgController.cameraSpeed()
+}
+
+// Sets the velocity of the reference frame the camera is tracking
+// in, in logical units per second. On each update, the camera is
+// first carried by this velocity and only then the tracker moves
+// it towards the target. This is useful when the player is riding
+// a moving platform: by setting the platform's velocity, the tracker
+// only sees the player's motion relative to the platform, removing
+// the trailing lag that fast platforms would otherwise cause.
+//
+// Setting the velocity back to (0, 0) resumes regular world frame
+// tracking. The reference frame is ignored while tracking is disabled.
+func (AccessorCamera) SetReferenceFrameVelocity(vx, vy float64) {
+	pkgController.cameraSetReferenceFrameVelocity(vx, vy)
+}
+
+// Animates the camera position and zoom together towards the given
+// target, over the same duration and with the same easing curve, so
+// both arrive simultaneously. This is the typical cinematic "focus
+// pull", which is hard to achieve with independent trackers and
+// zoomers, as their timings are not coordinated.
+//
+// While the focus pull is active, the regular tracker and zoomer are
+// bypassed. Once it ends, they resume their work with the focus pull
+// target as their own target. If easing is nil, linear easing is used.
+// The zoom must be in [0.05, 500.0] range.
+func (AccessorCamera) FocusOn(x, y, zoom float64, duration TicksDuration, easing func(t float64) float64) {
+	pkgController.cameraFocusOn(x, y, zoom, duration, easing)
+}
+
+// Returns whether a focus pull started with [AccessorCamera.FocusOn]()
+// is still in progress.
+func (AccessorCamera) IsFocusing() bool {
+	return pkgController.cameraIsFocusing()
+}
+
 // --- zoom ---
 
 // Sets a new target zoom level. The transition from the current
@@ -104,10 +373,99 @@ func (AccessorCamera) Zoom(newZoomLevel float64) {
 	pkgController.cameraZoom(newZoomLevel)
 }
 
+// Sets the allowed range for zoom targets. Any target passed to
+// [AccessorCamera.Zoom]() and similar methods, like [AccessorCamera.ZoomTowards](),
+// [AccessorCamera.FitRect]() or [AccessorCamera.FocusOn](), is clamped to this range before being
+// set. Useful when exposing zoom to players, e.g. through the scroll
+// wheel. The current target is also clamped immediately.
+//
+// Limits don't apply to [AccessorCamera.ResetZoom](). By default,
+// the range is [0, +Inf), so no clamping is applied.
+func (AccessorCamera) SetZoomLimits(minZoom, maxZoom float64) {
+	pkgController.cameraSetZoomLimits(minZoom, maxZoom)
+}
+
+// Returns the zoom limits. See [AccessorCamera.SetZoomLimits]().
+func (AccessorCamera) GetZoomLimits() (minZoom, maxZoom float64) {
+	return pkgController.cameraGetZoomLimits()
+}
+
+// Like [AccessorCamera.Zoom](), but the camera coordinates are
+// adjusted throughout the zoom transition so the given world point
+// remains under the same screen position. This is the standard
+// "zoom to cursor" behavior of map editors:
+//
+//	wx, wy := mipix.Convert().ToLogicalCoords(ebiten.CursorPosition())
+//	mipix.Camera().ZoomTowards(newZoom, wx, wy)
+//
+// The adjustment works by re-notifying the camera coordinates on
+// each update until the zoom reaches its target, so it interoperates
+// with any tracker, but the point will only stay perfectly still with
+// responsive trackers, like [tracker.Instant]. Calling [AccessorCamera.Zoom](),
+// [AccessorCamera.NotifyCoordinates](), [AccessorCamera.NotifyDelta]()
+// or [AccessorCamera.FocusOn]() cancels the adjustment. The adjustment also ends if the zoom gets
+// clamped by [ZoomSafetyClamp] before reaching its target.
+func (AccessorCamera) ZoomTowards(newZoomLevel float64, worldX, worldY float64) {
+	pkgController.cameraZoomTowards(newZoomLevel, worldX, worldY)
+}
+
+// Frames the given world rectangle: the tracking target is set to
+// the center of the rectangle, and the zoom target is set so the
+// rectangle, extended by the given padding on each side, fits the
+// viewport while preserving the aspect ratio. Transitions are
+// animated by the current [tracker.Tracker] and [zoomer.Zoomer],
+// like with [AccessorCamera.NotifyCoordinates]() and
+// [AccessorCamera.Zoom]().
+//
+// The resulting zoom level is clamped to [0.05, 500.0]. The
+// padding can't be negative, and the rectangle can't be inverted.
+// Useful to frame rooms, arenas and similar areas.
+func (AccessorCamera) FitRect(minX, minY, maxX, maxY float64, padding float64) {
+	pkgController.cameraFitRect(minX, minY, maxX, maxY, padding)
+}
+
+// Instantly sets the camera focus and zoom level so the visible
+// area matches the given world rectangle. If the rectangle's aspect
+// ratio doesn't match the viewport's, the zoom is chosen so the whole
+// rectangle remains visible, centered on the viewport.
+//
+// Unlike [AccessorCamera.FitRect](), no transitions are applied.
+// Mostly useful to restore saved views deterministically, or for
+// editors. The rectangle must have a positive width and height.
+func (AccessorCamera) SetArea(minX, minY, maxX, maxY float64) {
+	pkgController.cameraSetArea(minX, minY, maxX, maxY)
+}
+
+// Returns the zoom level at which the given world width would
+// exactly span the viewport horizontally. The result is not
+// clamped, so you might want to limit it before passing it to
+// [AccessorCamera.Zoom](). Stretching is taken into account.
+func (AccessorCamera) ZoomForWorldWidth(worldWidth float64) float64 {
+	return pkgController.cameraZoomForWorldSpan(worldWidth, 0.0)
+}
+
+// Like [AccessorCamera.ZoomForWorldWidth](), but for the
+// vertical span of the viewport.
+func (AccessorCamera) ZoomForWorldHeight(worldHeight float64) float64 {
+	return pkgController.cameraZoomForWorldSpan(0.0, worldHeight)
+}
+
 func (AccessorCamera) ResetZoom(zoomLevel float64) {
 	pkgController.cameraZoomReset(zoomLevel)
 }
 
+// Triggers a "zoom punch": a quick decaying oscillation of the zoom
+// level, layered on top of the regular zoom. This reads as an impact
+// without translating the view. The magnitude is relative to the
+// current zoom and determines the amplitude of the oscillation (e.g.
+// 0.08 for a subtle punch), and negative values punch out instead. The zoom target is not modified.
+//
+// Triggering a new pulse replaces the previous one. [AccessorCamera.GetZoom]()
+// doesn't include the pulse offset, but [AccessorCamera.Area]() does.
+func (AccessorCamera) TriggerZoomPulse(magnitude float64, duration TicksDuration) {
+	pkgController.cameraTriggerZoomPulse(magnitude, duration)
+}
+
 // Returns the current [zoomer.Zoomer] interface.
 // See [AccessorCamera.SetZoomer]() for more details.
 func (AccessorCamera) GetZoomer() zoomer.Zoomer {
@@ -126,6 +484,25 @@ func (AccessorCamera) GetZoom() (current, target float64) {
 	return pkgController.cameraGetZoom()
 }
 
+// See [AccessorCamera.SetZoomSafetyPolicy]().
+type ZoomSafetyPolicy uint8
+
+const (
+	ZoomSafetyPanic ZoomSafetyPolicy = iota // default, panic when zoom goes out of range
+	ZoomSafetyClamp                         // silently clamp the zoom to the safe range
+
+	zoomSafetyPolicyEndSentinel
+)
+
+// Sets how mipix reacts when the zoom goes outside the [0.005, 500.0]
+// safety range after a zoomer update. By default, this is considered a
+// fatal bug in the [zoomer.Zoomer] and causes a panic. With [ZoomSafetyClamp],
+// the zoom is silently clamped to the range instead, and NaN changes are
+// ignored, which is more robust for player-controlled zoom in production.
+func (AccessorCamera) SetZoomSafetyPolicy(policy ZoomSafetyPolicy) {
+	pkgController.cameraSetZoomSafetyPolicy(policy)
+}
+
 // --- screen shaking ---
 
 // Returns the shaker interface associated to the given shaker
@@ -147,6 +524,33 @@ func (AccessorCamera) SetShaker(shaker shaker.Shaker, channel ...shaker.Channel)
 	pkgController.cameraSetShaker(shaker, channel...)
 }
 
+// Registers a default shaker for the given channel. Default shakers
+// are used whenever the channel doesn't have an explicit shaker set
+// through [AccessorCamera.SetShaker](), which allows calling
+// [AccessorCamera.StartShake]() or [AccessorCamera.TriggerShake]()
+// on named channels without any prior setup.
+//
+// For channel zero, this replaces the fallback [shaker.Random].
+// Passing a nil shaker unregisters the default.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCamera) SetChannelDefault(channel shaker.Channel, shaker shaker.Shaker) {
+	pkgController.cameraSetChannelDefault(channel, shaker)
+}
+
+// Registers a handler to be invoked whenever the shake on the given
+// channel fully stops, right after the shaker receives its termination
+// call. This is more precise than polling [AccessorCamera.IsShaking](),
+// and it's useful to re-enable input or chain effects after a shake.
+//
+// The handler is invoked during the camera update, which happens
+// right after [Game].Update() or on [AccessorCamera.FlushCoordinates]().
+// Only one handler can be registered per channel, and passing
+// nil unregisters it.
+func (AccessorCamera) OnShakeEnd(channel shaker.Channel, handler func()) {
+	pkgController.cameraOnShakeEnd(channel, handler)
+}
+
 // Starts a screen shake that will continue indefinitely until
 // stopped by [AccessorCamera.EndShake](). If no shaker channel(s)
 // are specified, the shake will start on the default channel zero.
@@ -169,28 +573,323 @@ func (AccessorCamera) EndShake(fadeOut TicksDuration, channels ...shaker.Channel
 	pkgController.cameraEndShake(fadeOut, channels...)
 }
 
+// Starts a screen shake like [AccessorCamera.StartShake](), but only
+// if the relevant channels are not already shaking indefinitely. Shakes
+// that are fading out or were triggered with a specific duration will
+// be turned into indefinite shakes again, while shakes that are already
+// fading in or fully active are left untouched.
+//
+// This is designed for state-driven shakes (e.g., "while near lava"),
+// where you can call the method on every update without having to
+// track state transitions yourself. See also [AccessorCamera.EnsureNotShaking]().
+func (AccessorCamera) EnsureShaking(fadeIn TicksDuration, channels ...shaker.Channel) {
+	pkgController.cameraEnsureShaking(fadeIn, channels...)
+}
+
+// Ends a screen shake like [AccessorCamera.EndShake](), but only if
+// the relevant channels are shaking and not already fading out.
+// Counterpart of [AccessorCamera.EnsureShaking]().
+func (AccessorCamera) EnsureNotShaking(fadeOut TicksDuration, channels ...shaker.Channel) {
+	pkgController.cameraEnsureNotShaking(fadeOut, channels...)
+}
+
+// Freezes or unfreezes all screen shakes. While frozen, shakers
+// are not updated, shake timings and trauma don't advance, and the
+// current shake offsets are held. Unfreezing resumes all shakes
+// from where they were. Mostly useful to compose screenshots
+// mid-shake without pausing the rest of the game.
+func (AccessorCamera) SetShakeFrozen(frozen bool) {
+	pkgController.cameraSetShakeFrozen(frozen)
+}
+
+// Returns whether shakes are frozen.
+// See [AccessorCamera.SetShakeFrozen]() for more details.
+func (AccessorCamera) IsShakeFrozen() bool {
+	return pkgController.cameraIsShakeFrozen()
+}
+
+// Returns the total shake rotation, in radians, accumulated from all
+// channels whose shakers implement [shaker.RotationalShaker]. The
+// rotation is applied around the center of the viewport during the
+// final projection. Coordinate conversions and [AccessorHiRes.Draw]()
+// take the rotation into account, but other high resolution draws
+// like [AccessorHiRes.FillOverRect]() remain axis-aligned.
+func (AccessorCamera) GetShakeRotation() float64 {
+	return pkgController.cameraGetShakeRotation()
+}
+
 // If no shaker channel is specified, the function returns whether
-// any camera shake is active. If a shaker channel is specified, the
+// any camera shake is active, including trauma shakes (see
+// [AccessorCamera.AddTrauma]()). If a shaker channel is specified, the
 // function will only return whether that specific channel is active.
 func (AccessorCamera) IsShaking(channel ...shaker.Channel) bool {
 	return pkgController.cameraIsShaking(channel...)
 }
 
+// Sets the easing curves for the fade in and fade out ramps of the
+// given shaker channel. By default, ramps are linear (and then each
+// shaker applies its own normalization over the level). With custom
+// curves, you can shape the feel of the ramps independently of the
+// shaker's noise; e.g., a sharp attack with a slow release.
+//
+// Both curves receive the linear progress of the ramp, in [0, 1],
+// and must return the eased progress, like the easing functions in
+// the tween subpackage. For the fade out, the level passed to the
+// shaker is (1 - fadeOutCurve(t)). Nil curves are linear.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCamera) SetShakeFadeCurves(channel shaker.Channel, fadeInCurve, fadeOutCurve func(t float64) float64) {
+	pkgController.cameraSetShakeFadeCurves(channel, fadeInCurve, fadeOutCurve)
+}
+
+// Sets an exponent to be applied to the activity level of the given
+// channel before passing it to the shaker. With exponents above 1.0,
+// weak activity levels become weaker, which makes shakes decay with
+// a more natural, punchier falloff. For example, an exponent of 2.0
+// gives the classic squared "trauma" falloff. The exponent must be
+// strictly positive, and it defaults to 1.0 (linear).
+//
+// The exponent is applied after the fade curves and attenuations.
+// See also [AccessorCamera.SetShakeFadeCurves]().
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCamera) SetActivityExponent(channel shaker.Channel, exponent float64) {
+	pkgController.cameraSetActivityExponent(channel, exponent)
+}
+
+// Constrains the offsets of the given shaker channel to the allowed
+// axes. Disallowed axes are zeroed before the channel offsets are
+// combined. This allows reusing the same omni-directional shaker
+// on multiple channels, e.g., a vertical-only landing shake and a
+// horizontal-only skid shake. Both axes are allowed by default.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCamera) SetChannelAxisMask(channel shaker.Channel, allowX, allowY bool) {
+	pkgController.cameraSetChannelAxisMask(channel, allowX, allowY)
+}
+
+// The timing state of a shaker channel. See [AccessorCamera.ShakeChannelState]().
+type ShakeState struct {
+	FadeIn   TicksDuration
+	Duration TicksDuration // [IndefiniteTicks] for shakes that last until ended
+	FadeOut  TicksDuration
+	Elapsed  TicksDuration // ticks since the shake started, including the fade in
+}
+
+// Returns the full timing state of the given shaker channel.
+// Together with [AccessorCamera.SetShakeChannelState](), this
+// allows building tools to scrub and preview shake timelines.
+// Uninitialized channels return a zero state.
+func (AccessorCamera) ShakeChannelState(channel shaker.Channel) ShakeState {
+	return pkgController.cameraGetShakeChannelState(channel)
+}
+
+// Overwrites the full timing state of the given shaker channel.
+// Any triggered shake boosts and attenuations on the channel are
+// discarded. See [AccessorCamera.ShakeChannelState]() for more details.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCamera) SetShakeChannelState(channel shaker.Channel, state ShakeState) {
+	pkgController.cameraSetShakeChannelState(channel, state)
+}
+
 // Triggers a screenshake with specific fade in, duration and fade
 // out tick durations. If no explicit shaker channels are passed,
 // the trigger will be applied to the default channel zero.
 //
-// Currently, triggering a shake on a channel that's already active
-// and with an undefined duration will override the shake duration,
-// eventually bringing it to a stop. That being said, this is considered
-// an ambiguous situation that you should try to avoid in the first place.
+// Triggering a shake on a channel that already has an indefinite shake
+// in progress (see [AccessorCamera.StartShake]()) doesn't override the
+// continuous shake. Instead, the triggered shake is stacked on top as a
+// temporary boost: the shaker receives the max of both activity levels,
+// and once the triggered shake ends, the continuous shake resumes as if
+// nothing happened. [AccessorCamera.EndShake]() ends both. If you need
+// the two shakes to add up instead, use separate channels.
+//
+// Triggering a shake on a channel that's stopped, fading out or already
+// running a triggered shake simply replaces the previous timing.
 func (AccessorCamera) TriggerShake(fadeIn, duration, fadeOut TicksDuration, channels ...shaker.Channel) {
 	pkgController.cameraTriggerShake(fadeIn, duration, fadeOut, channels...)
 }
 
+// Like [AccessorCamera.TriggerShake](), but the shake is attenuated
+// based on the distance between the camera focus and the given world
+// point. The shake level decreases linearly with the distance, and
+// events beyond maxRange don't trigger any shake at all. Useful for
+// explosions and other positional events.
+//
+// Notice that the attenuation scales the level passed to the
+// [shaker.Shaker], so the exact effect on the amplitude depends on
+// the shaker implementation. Re-triggering a shake on the same
+// channel replaces the previous attenuation.
+func (AccessorCamera) TriggerShakeAt(worldX, worldY float64, maxRange float64, fadeIn, duration, fadeOut TicksDuration, channels ...shaker.Channel) {
+	pkgController.cameraTriggerShakeAt(worldX, worldY, maxRange, fadeIn, duration, fadeOut, channels...)
+}
+
+// Adds trauma to the camera. Trauma is an alternative shake model
+// where a trauma level in [0, 1] range accumulates additively from
+// events (hits, explosions...) and decays over time, while the
+// shake intensity is driven by trauma squared. This makes small
+// events barely noticeable and big or accumulated ones much more
+// intense.
+//
+// Trauma shakes are independent from shaker channels, and they use
+// their own shaker (see [AccessorCamera.SetTraumaShaker]()). The
+// resulting level is clamped to [0, 1], so negative amounts can
+// also be used to reduce the trauma.
+func (AccessorCamera) AddTrauma(amount float64) {
+	pkgController.cameraAddTrauma(amount)
+}
+
+// Returns the current trauma level, in [0, 1] range.
+// See [AccessorCamera.AddTrauma]() for more details.
+func (AccessorCamera) GetTrauma() float64 {
+	return pkgController.cameraGetTrauma()
+}
+
+// Sets how much trauma decays per second. Defaults to 1.0,
+// which means that max trauma takes one second to wear off.
+// See [AccessorCamera.AddTrauma]() for more details.
+func (AccessorCamera) SetTraumaDecay(perSecond float64) {
+	pkgController.cameraSetTraumaDecay(perSecond)
+}
+
+// Sets the shaker used for trauma shakes. The shaker receives
+// the squared trauma as the level. By default the shaker is nil,
+// and trauma is handled by a fallback [shaker.Random].
+func (AccessorCamera) SetTraumaShaker(shaker shaker.Shaker) {
+	pkgController.cameraSetTraumaShaker(shaker)
+}
+
+// Kicks the camera by the given offset, in logical units, and then
+// smoothly eases it back to zero over the given recovery duration.
+// Unlike shakes, recoils are deterministic and directional, which
+// makes them a staple for weapon feedback in shooters.
+//
+// Recoils are layered on top of regular tracking, like shake offsets,
+// and multiple recoils accumulate. The kick becomes visible on the
+// next camera update, so when called during [Game].Update(), it's
+// already visible on the next draw. Freezing shakes with
+// [AccessorCamera.SetShakeFrozen]() also holds the recoil offsets.
+func (AccessorCamera) ApplyRecoil(dx, dy float64, recovery TicksDuration) {
+	pkgController.cameraApplyRecoil(dx, dy, recovery)
+}
+
 // This might be interesting for ephemerous shakes, so you don't have to be tracking and managing
 // everything so manually. That being said, you would still need a pool and to manage everything
 // diligently, so maybe there's not much gain here.
 // func (AccessorCamera) TriggerEventShake(shaker shaker.Shaker, fadeIn, duration, fadeOut TicksDuration) {
 //
 // }
+
+// --- screen fades and overlays ---
+
+// Starts a full screen fade from the current fade alpha towards
+// the given target alpha, in [0, 1] range. The fade color is drawn
+// over the game's active area after the final projection, so it
+// covers both logical and high resolution content, but not the
+// letterboxing bars.
+//
+// The easing is applied over the fade progress and can be any of
+// the functions in the tween subpackage, like tween.CubicSmoothstep.
+// Linear fades can feel abrupt at the ends, so smooth easings are
+// generally recommended. If nil, linear easing is used.
+//
+// Typical usage:
+//
+//	mipix.Camera().StartFade(color.Black, 1.0, 60, tween.CubicSmoothstep) // fade out
+//	mipix.Camera().StartFade(color.Black, 0.0, 60, tween.CubicSmoothstep) // fade in
+func (AccessorCamera) StartFade(fadeColor color.Color, toAlpha float64, duration TicksDuration, easing func(t float64) float64) {
+	pkgController.cameraStartFade(fadeColor, toAlpha, duration, easing)
+}
+
+// Returns the current fade alpha, in [0, 1] range. This is the
+// already eased value, so it can be used directly to sync other
+// effects to the fade, like audio ducking.
+func (AccessorCamera) GetFadeAlpha() float64 {
+	return pkgController.cameraGetFadeAlpha()
+}
+
+// Returns whether a fade started with [AccessorCamera.StartFade]()
+// is still in progress.
+func (AccessorCamera) IsFading() bool {
+	return pkgController.cameraIsFading()
+}
+
+// Identifier for overlays created with [AccessorCamera.AddOverlay]().
+// The zero value is never a valid identifier.
+type OverlayID uint32
+
+// Adds a full screen color overlay that fades in, holds at full
+// opacity and fades out over the given durations, and is then removed
+// automatically. Multiple overlays can run simultaneously, each with
+// its own timeline, which makes it easy to layer effects like damage
+// flashes and scene transitions.
+//
+// Overlays are drawn in the order they were added, below the fade
+// from [AccessorCamera.StartFade](). The returned identifier can be
+// used to cancel the overlay early with [AccessorCamera.CancelOverlay]().
+func (AccessorCamera) AddOverlay(clr color.Color, in, hold, out TicksDuration) OverlayID {
+	return pkgController.cameraAddOverlay(clr, in, hold, out)
+}
+
+// Removes the given overlay immediately. Returns false if the
+// overlay had already finished or the identifier is invalid.
+func (AccessorCamera) CancelOverlay(id OverlayID) bool {
+	return pkgController.cameraCancelOverlay(id)
+}
+
+// See [AccessorCamera.SetRevealMask]().
+type MaskShape uint8
+
+const (
+	MaskNone           MaskShape = iota // default, no reveal mask
+	MaskCircle                          // iris expanding from the center of the screen
+	MaskWipeHorizontal                  // wipe revealing from left to right
+	MaskWipeVertical                    // wipe revealing from top to bottom
+
+	maskShapeEndSentinel
+)
+
+// Sets a reveal mask for iris and wipe transitions. Only the
+// revealed region shows the game, while the rest of the active area
+// is covered with the color set through [AccessorCamera.SetRevealMaskColor]()
+// (black by default). The progress must be in [0, 1] range, where
+// 0 means fully hidden and 1 fully revealed.
+//
+// Like fades, the mask is applied after the final projection, so
+// it covers both logical and high resolution content. It's drawn
+// below overlays and fades. To animate the transition, call this
+// method on each update with the new progress, e.g. driven by a
+// tween from the tween subpackage. Once the transition ends, you
+// can set [MaskNone].
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCamera) SetRevealMask(shape MaskShape, progress float64) {
+	pkgController.cameraSetRevealMask(shape, progress)
+}
+
+// Sets the color used for the hidden region of the reveal mask.
+// See [AccessorCamera.SetRevealMask]() for more details.
+func (AccessorCamera) SetRevealMaskColor(clr color.Color) {
+	pkgController.cameraSetRevealMaskColor(clr)
+}
+
+// --- independent views ---
+
+// Renders an independent view of the world into the given target,
+// without disturbing the main camera. Typically used for minimaps
+// and other render-to-texture effects.
+//
+// The view is centered at the given world coordinates, and the zoom
+// is relative to the target size: with zoom = 0.25 and a 64x64 target,
+// a 256x256 world area will be rendered. The draw function receives
+// a logical canvas for that area, and while it runs, [AccessorCamera.Area]()
+// and related functions report the view area instead of the main camera
+// area, so you can reuse your regular drawing code. The results are
+// then projected into the target with the current scaling filter.
+//
+// Must only be called during the draw stage. Nested calls are not
+// allowed.
+func (AccessorCamera) RenderViewTo(target *ebiten.Image, centerX, centerY, zoom float64, drawFunc func(canvas *ebiten.Image)) {
+	pkgController.cameraRenderViewTo(target, centerX, centerY, zoom, drawFunc)
+}
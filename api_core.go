@@ -2,6 +2,7 @@ package mipix
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 
 	ebimath "github.com/edwinsyarief/ebi-math"
@@ -96,6 +97,78 @@ func LayoutHasChanged() bool {
 	return pkgController.layoutHasChanged
 }
 
+// See [SetPanicPolicy]().
+type PanicPolicy uint8
+
+const (
+	PolicyPanic  PanicPolicy = iota // default, panic on misuse
+	PolicyLog                       // log the misuse and ignore the call
+	PolicyIgnore                    // silently ignore the call
+
+	panicPolicyEndSentinel
+)
+
+// Sets how mipix reacts to non-fatal misuse of the API, like
+// calling [AccessorCamera.Zoom]() during the draw stage or passing
+// invalid arguments to most setters. By default, mipix panics
+// loudly, which is what you want during development. For shipping
+// builds, you might prefer logging the problem and turning the
+// offending call into a no-op instead.
+//
+// Fatal errors, like running the game without setting a resolution,
+// zoomers going out of control (see [AccessorCamera.SetZoomSafetyPolicy]())
+// or shaders failing to compile without an [AccessorScaling.OnShaderError]()
+// handler, always panic.
+func SetPanicPolicy(policy PanicPolicy) {
+	pkgController.setPanicPolicy(policy)
+}
+
+// Requests a capture of the logical canvas, upscaled by the given
+// integer factor with nearest neighbor interpolation. Unlike a full
+// screen capture, this only includes the pixel art content, without
+// high resolution draws, debug info or any scaling filter smoothing,
+// which makes it ideal for sharing crisp pixel art screenshots.
+//
+// The capture is asynchronous: the logical canvas is read right after
+// the next [Game].Draw() call, and the callback is invoked at that
+// point with the result. Draws queued with [QueueDraw]() are not included.
+// Notice that the logical canvas might be slightly bigger than the game
+// resolution when the camera is moving smoothly or zoomed out.
+func CaptureLogical(scale int, callback func(*image.RGBA)) {
+	pkgController.captureLogical(scale, callback)
+}
+
+// See [ProjectToTarget]().
+type FitMode uint8
+
+const (
+	FitContain FitMode = iota // fit the whole camera area, leaving bars if necessary
+	FitCover                  // cover the whole target, cropping the camera area if necessary
+	FitStretch                // project the camera area to the whole target, distorting it
+
+	fitModeEndSentinel
+)
+
+// Renders the current camera area into an arbitrary target, with an
+// aspect ratio that might differ from the logical one. The draw function
+// receives a logical canvas for the camera area, just like [Game].Draw(),
+// and the results are projected with the current scaling filter, fitting
+// the target according to the given mode. This is independent of the
+// window layout, which makes it useful to embed the game view on UI
+// panels, editors and similar.
+//
+// With [FitContain], the bars are left untouched, so you might want to
+// fill the target before the call. With [FitCover], the camera area is
+// cropped, and while the draw function runs, [AccessorCamera.Area]()
+// reports the cropped area. For different view positions or zoom
+// levels, see [AccessorCamera.RenderViewTo]() instead.
+//
+// Must only be called during the draw stage. Nested calls are not
+// allowed.
+func ProjectToTarget(target *ebiten.Image, fit FitMode, drawFunc func(canvas *ebiten.Image)) {
+	pkgController.projectToTarget(target, fit, drawFunc)
+}
+
 // --- high resolution drawing ---
 
 // See [HiRes]().
@@ -115,6 +188,47 @@ func (self AccessorHiRes) Height() int {
 	return pkgController.hiResHeight
 }
 
+// Returns the bounds of the active high resolution area for the
+// current frame. Unlike [AccessorHiRes.Width]() and [AccessorHiRes.Height](),
+// this excludes the letterboxing margins, so it's what you typically
+// need for high resolution HUD layouts.
+//
+// The bounds are updated at the start of each draw, so during
+// [Game].Update() you get the values from the previous frame.
+func (self AccessorHiRes) ActiveBounds() image.Rectangle {
+	return pkgController.hiResActiveBounds()
+}
+
+// See [AccessorHiRes.AnchorPoint]().
+type Anchor uint8
+
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+
+	anchorEndSentinel
+)
+
+// Returns the high resolution position of the given anchor within
+// the active area (see [AccessorHiRes.ActiveBounds]()), which makes
+// it easy to pin HUD elements to the letterboxed viewport edges
+// instead of the raw canvas.
+//
+// Margins are applied inwards for anchors on edges, and as plain
+// offsets for centered axes. For example, [AnchorBottomRight] with
+// margins (8, 4) returns the point 8 pixels left and 4 pixels up
+// from the bottom right corner of the active area.
+func (self AccessorHiRes) AnchorPoint(anchor Anchor, marginX, marginY int) (x, y float64) {
+	return pkgController.hiResAnchorPoint(anchor, marginX, marginY)
+}
+
 // Draws the source into the given target at the given global logical
 // coordinates (camera origin is automatically subtracted).
 //
@@ -135,13 +249,47 @@ func (self AccessorHiRes) Draw(target, source *ebiten.Image, transform *ebimath.
 	pkgController.hiResDraw(target, source, transform)
 }
 
+// Draws the source into the given target at the given high resolution
+// pixel coordinates, relative to the target's top-left corner. No camera
+// transform or scaling is applied. When the target is the active viewport
+// received on [QueueHiResDraw]() handlers, this is the simplest way to
+// place fixed HUD elements without worrying about letterboxing.
+func (self AccessorHiRes) DrawScreen(target, source *ebiten.Image, screenX, screenY float64) {
+	pkgController.hiResDrawScreen(target, source, screenX, screenY)
+}
+
+// Rounds the given high resolution coordinates to the device pixel
+// grid. Projected positions are typically fractional, and drawing
+// vectorial text or other crisp hi-res elements at those positions
+// makes them shimmer as the camera moves. Snapping their baselines
+// with this method keeps them sharp.
+//
+// The coordinates must be given in the same space as the canvases
+// received on [QueueHiResDraw]() handlers.
+func (self AccessorHiRes) SnapToDevicePixels(x, y float64) (float64, float64) {
+	return pkgController.hiResSnapToDevicePixels(x, y)
+}
+
 // Fills the logical area designated by the given coordinates with fillColor.
 // If you need fills with alpha blending directly without high resolution,
 // see the utils subpackage.
+//
+// The rect is always axis-aligned, so the shake rotation is not applied.
 func (self AccessorHiRes) FillOverRect(target *ebiten.Image, minX, minY, maxX, maxY float64, fillColor color.Color) {
 	pkgController.hiResFillOverRect(target, minX, minY, maxX, maxY, fillColor)
 }
 
+// Draws a crosshair marker centered at the given global logical
+// coordinates. The size is given in logical pixels, and the lines
+// are about half a logical pixel thick. The marker follows the
+// camera zoom and shakes like any other logical content, but it stays
+// axis-aligned even when the shake rotation is non-zero.
+//
+// Handy for debugging positions and for simple reticles.
+func (self AccessorHiRes) DrawMarker(target *ebiten.Image, worldX, worldY float64, size float64, clr color.Color) {
+	pkgController.hiResDrawMarker(target, worldX, worldY, size, clr)
+}
+
 // --- scaling ---
 
 // See [Scaling]().
@@ -231,6 +379,59 @@ func (self ScalingFilter) String() string {
 	}
 }
 
+// Returns the camera zoom range within which the filter is
+// expected to produce good results. This is purely informational
+// metadata that can be used to warn players on settings menus; it
+// doesn't affect any behavior.
+//
+// The values are derived from the filter characteristics: unstable
+// filters like [Nearest] look bad at low and fractional zooms, while
+// expensive filters like [Bicubic] are wasteful at high zooms, where
+// cheaper filters already look good.
+func (self ScalingFilter) RecommendedZoomRange() (minZoom, maxZoom float64) {
+	switch self {
+	case AASamplingSoft:
+		return 0.5, 16.0
+	case AASamplingSharp:
+		return 0.75, 16.0
+	case Nearest:
+		return 1.0, 500.0
+	case Hermite:
+		return 0.5, 8.0
+	case Bicubic:
+		return 0.25, 2.0
+	case Bilinear:
+		return 0.25, 4.0
+	case SrcHermite, SrcBicubic, SrcBilinear:
+		return 0.25, 1.5
+	default:
+		panic("invalid ScalingFilter")
+	}
+}
+
+// Returns the approximate softness of the filter, in [0, 1] range,
+// where 0 is the sharpest ([Nearest]) and 1 the blurriest. Like
+// [ScalingFilter.RecommendedZoomRange](), this is only informational
+// metadata, mostly useful to sort filters on settings menus.
+func (self ScalingFilter) Softness() float64 {
+	switch self {
+	case Nearest:
+		return 0.0
+	case AASamplingSharp:
+		return 0.25
+	case AASamplingSoft:
+		return 0.45
+	case Hermite, SrcHermite:
+		return 0.65
+	case Bicubic, SrcBicubic:
+		return 0.8
+	case Bilinear, SrcBilinear:
+		return 1.0
+	default:
+		panic("invalid ScalingFilter")
+	}
+}
+
 // Set to true to avoid black borders and completely fill the screen
 // no matter how ugly it gets. By default, stretching is disabled. In
 // general you only want to expose stretching as a setting for players;
@@ -247,6 +448,128 @@ func (AccessorScaling) GetStretchingAllowed() bool {
 	return pkgController.scalingGetStretchingAllowed()
 }
 
+// With fractional camera positions, the logical canvas received on
+// [Game].Draw() is typically one pixel wider and taller than the
+// game resolution, as the camera area has to be rounded outwards
+// to integer coordinates. This is necessary for smooth camera
+// movement, but it can be inconvenient for full-screen logical
+// effects.
+//
+// With a tight canvas, the camera position is snapped to integer
+// logical coordinates, so the canvas matches the visible area
+// exactly (whenever the zoomed area has an integer size, like
+// at zoom = 1.0). The trade-off is that camera movement will
+// no longer be smooth. Defaults to false.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetTightCanvas(tight bool) {
+	pkgController.scalingSetTightCanvas(tight)
+}
+
+// Returns whether the tight canvas mode is enabled.
+// See [AccessorScaling.SetTightCanvas]() for more details.
+func (AccessorScaling) GetTightCanvas() bool {
+	return pkgController.scalingGetTightCanvas()
+}
+
+// With a fixed logical canvas, [Game].Draw() always receives a canvas
+// of exactly the game resolution, framed as if the zoom was 1.0, and
+// [AccessorCamera.Area]() reports that canvas area. Zooming in is
+// applied during the projection instead, which makes the draw
+// contract simpler and more stable for code that assumes a constant
+// canvas size.
+//
+// The trade-offs are that zooming out is no longer possible (zoom
+// levels below 1.0 are treated as 1.0), camera movement is snapped
+// to the logical pixel grid, and stretching doesn't expand the
+// visible area. Defaults to false.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetFixedLogicalCanvas(fixed bool) {
+	pkgController.scalingSetFixedLogicalCanvas(fixed)
+}
+
+// Returns whether the fixed logical canvas mode is enabled.
+// See [AccessorScaling.SetFixedLogicalCanvas]() for more details.
+func (AccessorScaling) GetFixedLogicalCanvas() bool {
+	return pkgController.scalingGetFixedLogicalCanvas()
+}
+
+// See [AccessorScaling.SetOrientation]().
+type Orientation uint8
+
+const (
+	Orientation0   Orientation = iota // default, no rotation
+	Orientation90                     // quarter turn clockwise
+	Orientation180                    // half turn
+	Orientation270                    // quarter turn counter-clockwise
+
+	orientationEndSentinel
+)
+
+// Sets a fixed quarter-turn rotation for the logical content
+// projection. Useful for games that support device rotation on
+// mobile, where the logical canvas has to be displayed in portrait
+// or landscape mode independently of the screen orientation.
+//
+// With [Orientation90] or [Orientation270], the logical width and
+// height are swapped when fitting the canvas to the screen, so a
+// 180x320 game will fill a 320x180 screen. [AccessorConvert] functions
+// take the orientation into account, so screen coordinates keep
+// mapping to the right logical positions.
+//
+// This is unrelated to free camera rotation. Notice also that
+// [AccessorHiRes] draws and debug info operate directly in
+// screen space and are not rotated. Defaults to [Orientation0].
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetOrientation(orientation Orientation) {
+	pkgController.scalingSetOrientation(orientation)
+}
+
+// Returns the current orientation.
+// See [AccessorScaling.SetOrientation]() for more details.
+func (AccessorScaling) GetOrientation() Orientation {
+	return pkgController.scalingGetOrientation()
+}
+
+// When stretching is not allowed and the window aspect ratio doesn't
+// match the game's, the screen will have bars on the sides of the
+// active area. This function sets the colors to fill them with:
+// horizontal bars are the top and bottom bars (letterboxing), while
+// vertical bars are the left and right bars (pillarboxing). A nil
+// color leaves the relevant bars untouched, which is the default.
+//
+// See also [AccessorScaling.GetBars]().
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetPillarboxColors(horizontalBarColor, verticalBarColor color.Color) {
+	pkgController.scalingSetPillarboxColors(horizontalBarColor, verticalBarColor)
+}
+
+// Returns whether horizontal bars (top and bottom) and vertical bars
+// (left and right) were visible on the last drawn frame.
+// See [AccessorScaling.SetPillarboxColors]() for more details.
+func (AccessorScaling) GetBars() (horizontal, vertical bool) {
+	return pkgController.scalingGetBars()
+}
+
+// Returns whether the device scale factor has changed during
+// the last layout, typically due to monitor or DPI changes.
+// Unlike [LayoutHasChanged](), window resizes are not reported.
+// Useful to reload higher resolution assets only when needed.
+//
+// The initial device scale factor is not considered a change.
+func (AccessorScaling) DeviceScaleChanged() bool {
+	return pkgController.scalingDeviceScaleChanged()
+}
+
+// Returns the device scale factor used on the last layout.
+// See also [AccessorScaling.DeviceScaleChanged]().
+func (AccessorScaling) DeviceScale() float64 {
+	return pkgController.scalingDeviceScale()
+}
+
 func (AccessorScaling) SetBestFitContextSize(width, height int) {
 	pkgController.setBestFitContextSize(width, height)
 	pkgController.setBestFitRenderSize(pkgController.logicalWidth, pkgController.logicalHeight)
@@ -264,11 +587,209 @@ func (AccessorScaling) SetFilter(filter ScalingFilter) {
 	pkgController.scalingSetFilter(filter)
 }
 
+// Like [AccessorScaling.SetFilter](), but instead of switching
+// abruptly, both filters are rendered during the given duration
+// and alpha-blended in the projection. Useful to smoothly preview
+// filter changes on settings menus.
+//
+// The crossfade requires an additional shader pass into a temporary
+// buffer, so it's more expensive than regular projections. Calling
+// [AccessorScaling.SetFilter]() during a crossfade cancels it.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) CrossfadeFilter(to ScalingFilter, duration TicksDuration) {
+	pkgController.scalingCrossfadeFilter(to, duration)
+}
+
+// Sets a secondary filter to be used automatically while the camera
+// is in transition, which happens when the zoom is changing or the
+// camera is moving faster than one screen per second. When the camera
+// comes to rest, the main filter set with [AccessorScaling.SetFilter]()
+// is restored.
+//
+// This allows using cheaper filters (e.g. [Bilinear]) during fast
+// transitions, where quality differences are hard to notice anyway,
+// while keeping crisper but more expensive filters at rest. The
+// shader for the transition filter is compiled immediately. If it
+// fails to compile, the error is reported through the handler set
+// with [AccessorScaling.OnShaderError]() and the call has no effect.
+// Later calls with the same filter are silently ignored.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetTransitionFilter(filter ScalingFilter) {
+	pkgController.scalingSetTransitionFilter(filter)
+}
+
+// Removes the transition filter previously set with
+// [AccessorScaling.SetTransitionFilter]().
+func (AccessorScaling) ClearTransitionFilter() {
+	pkgController.scalingClearTransitionFilter()
+}
+
+// Returns the transition filter and whether it's set.
+// See [AccessorScaling.SetTransitionFilter]() for more details.
+func (AccessorScaling) GetTransitionFilter() (filter ScalingFilter, isSet bool) {
+	return pkgController.scalingGetTransitionFilter()
+}
+
+// Returns whether a filter crossfade is in progress.
+// See [AccessorScaling.CrossfadeFilter]() for more details.
+func (AccessorScaling) IsCrossfading() bool {
+	return pkgController.scalingIsCrossfading()
+}
+
+// Compiles the shader of the next scaling filter that hasn't been
+// compiled yet, and returns true once all filters are compiled.
+// This can be used to spread shader compilation across multiple
+// frames of a loading screen, avoiding the hitches that compiling
+// many shaders at once would cause:
+//
+//	// on each loading screen update:
+//	if mipix.Scaling().CompileIncrementally() { /* done */ }
+//
+// Filters that fail to compile are reported to the
+// [AccessorScaling.OnShaderError]() handler and skipped.
+// Without a handler, compilation failures panic.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) CompileIncrementally() bool {
+	return pkgController.scalingCompileIncrementally()
+}
+
+// Returns whether the shader for the given filter has already
+// been compiled. See also [AccessorScaling.CompileIncrementally]().
+func (AccessorScaling) IsCompiled(filter ScalingFilter) bool {
+	return pkgController.scalingIsCompiled(filter)
+}
+
 // Returns the current scaling filter. The default is [AASamplingSoft].
 func (AccessorScaling) GetFilter() ScalingFilter {
 	return pkgController.scalingGetFilter()
 }
 
+// A single "smoothing" knob for players, as an alternative to
+// exposing every scaling filter. The level must be in [0, 1] range,
+// and it's matched against [ScalingFilter.Softness](): 0 picks [Nearest],
+// 1 picks [Bilinear], and intermediate values pick the sharpest filter
+// that's at least as soft as the level, with its AA width narrowed
+// to make it as sharp as the level requires. This makes the knob
+// roughly continuous instead of jumping between filters.
+//
+// [AccessorScaling.GetFilter]() will report the selected filter.
+// Setting a filter explicitly afterwards restores its default
+// AA width.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetQuality(level float64) {
+	pkgController.scalingSetQuality(level)
+}
+
+// Enables or disables a fast projection path for integer scaling.
+// When enabled and the [Nearest] filter is active, projections where
+// the logical canvas maps to the screen with an exact integer factor
+// and no rotation are done with a plain nearest [ebiten.Image.DrawImage]()
+// instead of the scaling shader. This can meaningfully reduce the GPU
+// cost on low-end devices for pixel-perfect setups.
+//
+// Projections that don't meet the conditions keep using the shader.
+// Defaults to false.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetFastIntegerPath(enabled bool) {
+	pkgController.scalingSetFastIntegerPath(enabled)
+}
+
+// Returns whether the fast integer projection path is enabled.
+// See [AccessorScaling.SetFastIntegerPath]() for more details.
+func (AccessorScaling) GetFastIntegerPath() bool {
+	return pkgController.scalingGetFastIntegerPath()
+}
+
+// Scales the final projected image by the given factor, around the
+// center of the active area. Unlike camera zoom, which changes how
+// much of the world is visible, this keeps the world sampling intact
+// and only changes how big the image is drawn. This can be used for
+// "dolly zoom"-ish effects and UI transitions.
+//
+// With factors below 1.0, the uncovered area is cleared. With factors
+// above 1.0, the image is cropped to the active area. The main projection
+// and coordinate conversions are affected, high resolution draws are not.
+// Defaults to 1.0.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetViewportScale(factor float64) {
+	pkgController.scalingSetViewportScale(factor)
+}
+
+// Returns the current viewport scale.
+// See [AccessorScaling.SetViewportScale]() for more details.
+func (AccessorScaling) GetViewportScale() float64 {
+	return pkgController.scalingGetViewportScale()
+}
+
+// When the window is minimized, the layout might report zero or
+// degenerate sizes. mipix guards its scaling math against those
+// cases in all situations, but with skipDraw = true, draws are skipped
+// altogether while the layout size is degenerate: neither [Game].Draw()
+// nor queued draws are invoked, and the queued draws are discarded.
+// Skipped frames are reported as not redrawn by [AccessorDebug.LastFrameRedrawn](),
+// and any pending redraw is kept until the next regular frame.
+// Defaults to false.
+func (AccessorScaling) SetMinimizedBehavior(skipDraw bool) {
+	pkgController.scalingSetMinimizedBehavior(skipDraw)
+}
+
+// Returns a snapshot of the uniform values most recently sent to
+// the scaling shaders, like "SourceRelativeTextureUnitX" and
+// "SourceRelativeTextureUnitY". The returned map is a copy, so
+// modifying it has no effect.
+//
+// Mostly useful for debugging and to write custom shaders that
+// match the conventions of the built-in filters.
+func (AccessorScaling) CurrentUniforms() map[string]any {
+	return pkgController.scalingCurrentUniforms()
+}
+
+// Configures a soft glow post effect for bright areas of the game.
+// The effect thresholds the projected frame by luminance, blurs it
+// and adds the result back over the original. The threshold must be
+// in [0, 1] range; pixels with lower luminance won't glow. Intensity
+// must be non-negative, and reasonable values are typically within
+// [0.2, 1.5]. Setting the intensity to zero disables the effect,
+// which is also the default.
+//
+// The effect is applied over the whole active high resolution
+// canvas, including high resolution draws. It's a cheap single
+// pass approximation, not a physically accurate bloom.
+//
+// If the bloom shader fails to compile and an [AccessorScaling.OnShaderError]()
+// handler has been set, the error is reported to the handler and
+// the effect is disabled.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorScaling) SetBloom(threshold, intensity float64) {
+	pkgController.scalingSetBloom(threshold, intensity)
+}
+
+// Returns the current bloom parameters. See [AccessorScaling.SetBloom]().
+func (AccessorScaling) GetBloom() (threshold, intensity float64) {
+	return pkgController.scalingGetBloom()
+}
+
+// Sets a handler to be invoked if a filter's shader fails to compile.
+// By default the handler is nil and compilation errors cause a panic.
+// With a handler set, the error is reported to it instead and the
+// scaling filter falls back to [Nearest]. Bloom shader failures are
+// also reported, with the current scaling filter and an error prefixed
+// by "bloom shader", and they disable the bloom effect instead.
+//
+// Shader compilation failures are extremely rare, but some exotic
+// GPUs and drivers (particularly on mobile) might have trouble with
+// the more complex filters. A failed filter shouldn't crash the game.
+func (AccessorScaling) OnShaderError(handler func(filter ScalingFilter, err error)) {
+	pkgController.scalingOnShaderError(handler)
+}
+
 // --- conversions ---
 
 // See [Convert]().
@@ -290,6 +811,25 @@ func (AccessorConvert) ToLogicalCoords(x, y int) (float64, float64) {
 	return pkgController.convertToLogicalCoords(x, y)
 }
 
+// Transforms global logical coordinates to the screen pixel where they
+// currently appear, accounting for camera position, zoom, shakes (shake
+// rotation included), viewport scale, letterboxing margins and orientation. This is the inverse of
+// [AccessorConvert.ToLogicalCoords](), and it's commonly used to position
+// native Ebitengine UI elements on top of specific world entities.
+//
+// Coordinates outside the viewport are not clamped. See also
+// [AccessorConvert.FromLogicalCoordsF64]().
+func (AccessorConvert) FromLogicalCoords(x, y float64) (int, int) {
+	return pkgController.convertFromLogicalCoords(x, y)
+}
+
+// Like [AccessorConvert.FromLogicalCoords](), but without truncating
+// the results, which allows sub-pixel placement of smoothly moving
+// elements.
+func (AccessorConvert) FromLogicalCoordsF64(x, y float64) (float64, float64) {
+	return pkgController.convertFromLogicalCoordsF64(x, y)
+}
+
 // Transforms coordinates obtained from [ebiten.CursorPosition]() and
 // similar functions to relative screen coordinates between 0 and 1.
 //
@@ -310,6 +850,24 @@ func (AccessorConvert) ToGameResolution(x, y int) (float64, float64) {
 	return pkgController.convertToGameResolution(x, y)
 }
 
+// Returns the full transform from global logical coordinates to
+// the high resolution canvas (the first argument of [QueueHiResDraw]()
+// handlers), including camera position, zoom, shakes (shake rotation
+// included), viewport scale, letterboxing margins and orientation.
+//
+// Useful to draw arbitrary Ebitengine content aligned to the game
+// world, or to interoperate with libraries that take an [ebiten.GeoM]:
+//
+//	opts.GeoM = myLocalTransform
+//	opts.GeoM.Concat(mipix.Convert().WorldToScreenGeoM())
+//	hiResCanvas.DrawImage(img, &opts)
+//
+// The active area is updated at the start of each draw, so calling
+// this during [Game].Update() uses the bounds from the previous frame.
+func (AccessorConvert) WorldToScreenGeoM() ebiten.GeoM {
+	return pkgController.convertWorldToScreenGeoM()
+}
+
 // --- debug ---
 
 // See [Debug]().
@@ -332,6 +890,107 @@ func (AccessorDebug) Drawf(format string, args ...any) {
 	pkgController.debugDrawf(format, args...)
 }
 
+// Returns the size that the given text takes when rendered with
+// the debug bitmap font. To draw text with the same font on your
+// own canvases, see utils.DrawText().
+func (AccessorDebug) MeasureText(text string) (width, height int) {
+	return pkgController.debugMeasureText(text)
+}
+
+// Returns whether the most recent [Game].Draw() actually projected
+// the frame, or reused the previous one because no redraw was needed.
+// Without [AccessorRedraw.SetManaged](true), this is always true.
+//
+// Mostly useful to verify managed redraw setups: on static scenes,
+// you should see mostly false values. If every frame is redrawn,
+// something is requesting redraws when it shouldn't.
+func (AccessorDebug) LastFrameRedrawn() bool {
+	return pkgController.debugLastFrameRedrawn()
+}
+
+// Returns descriptions of the draws queued during the most recent
+// [Game].Draw(), in execution order. Each entry indicates whether
+// the draw was queued with [QueueDraw]() or [QueueHiResDraw](), and
+// the handler function name when it can be determined, e.g.:
+//
+//	#0 logical main.(*Game).drawBackground-fm
+//	#1 hi-res main.(*Game).drawHUD-fm
+//
+// Useful to diagnose layering problems.
+func (AccessorDebug) DumpDrawQueue() []string {
+	return pkgController.debugDumpDrawQueue()
+}
+
+// See [AccessorDebug.SetCorner]().
+type Corner uint8
+
+const (
+	TopLeft Corner = iota
+	TopRight
+	BottomLeft
+	BottomRight
+
+	cornerEndSentinel
+)
+
+// Sets the screen corner where [AccessorDebug.Drawf]() info is
+// rendered. The default is [TopLeft]. Useful to keep the debug
+// text away from HUD elements.
+func (AccessorDebug) SetCorner(corner Corner) {
+	pkgController.debugSetCorner(corner)
+}
+
+// By default, debug info is drawn at the very end of the frame,
+// on top of everything else. If beforeHiRes is true, debug info is
+// instead drawn right after the first logical projection and before
+// any [QueueHiResDraw]() handlers run, so your high resolution HUD
+// will be drawn on top of it. If no high resolution draws are queued,
+// the debug info is still drawn at the end of the frame.
+func (AccessorDebug) SetDrawOrder(beforeHiRes bool) {
+	pkgController.debugSetDrawOrder(beforeHiRes)
+}
+
+// Enables or disables a ruler overlay that displays logical
+// coordinates along the top and left edges of the screen. The
+// ruler follows the camera area and adapts its tick spacing to
+// the zoom level, which makes it a handy tool for inspecting
+// levels and aligning art.
+//
+// Like [AccessorDebug.Drawf](), the ruler is rendered at the end
+// of the draw, on top of everything else.
+func (AccessorDebug) DrawRuler(enabled bool) {
+	pkgController.debugSetRuler(enabled)
+}
+
+// Enables or disables lenient stage checks. When lenient, calls
+// made at the wrong stage, like [AccessorCamera.Zoom]() during
+// [Game].Draw(), are logged and turned into no-ops instead of being
+// handled according to the [SetPanicPolicy]() policy. Other kinds of
+// misuse are not affected.
+//
+// Handy for game jams and prototyping, when a panic deep inside
+// mipix would be more harmful than a misplaced call. Defaults
+// to false.
+func (AccessorDebug) SetLenient(lenient bool) {
+	pkgController.debugSetLenient(lenient)
+}
+
+// Enables or disables a profiling overlay that divides the screen
+// in a 16x9 grid and tints each cell based on how often it has been
+// redrawn during the last 64 frames, from blue (rarely redrawn) to
+// red (redrawn every frame). The exact frame redraw count is also
+// added to the [AccessorDebug.Drawf]() info.
+//
+// This is mostly useful together with [AccessorRedraw.SetManaged](true),
+// in order to catch needless redraws on static scenes. Redraws issued
+// through [AccessorRedraw.RequestArea]() only heat up the cells they
+// overlap, while any other redraw heats up the whole screen. Hot
+// regions reveal where the game is requesting more redraws than it
+// should.
+func (AccessorDebug) DrawRedrawHeatmap(enabled bool) {
+	pkgController.debugSetRedrawHeatmap(enabled)
+}
+
 // Similar to [fmt.Printf](), but expects two tick counts as the first
 // arguments. The function will only print during the period elapsed
 // between those two tick counts.
@@ -357,6 +1016,14 @@ func (AccessorDebug) Printfk(key ebiten.Key, format string, args ...any) {
 	pkgController.debugPrintfk(key, format, args...)
 }
 
+// Similar to [AccessorDebug.Printfk](), but only prints on the tick
+// where the key has just been pressed, instead of every tick while
+// the key is held. Useful to log single keypress events without
+// spamming the terminal.
+func (AccessorDebug) Printfkp(key ebiten.Key, format string, args ...any) {
+	pkgController.debugPrintfkp(key, format, args...)
+}
+
 // --- ticks ---
 
 // See [Tick]().
@@ -403,11 +1070,49 @@ func (AccessorTick) TPS() int {
 // Notice that this is not [ebiten.SetTPS](), as ebipixel considers
 // a more advanced model for [ticks and updates].
 //
+// The tick rate must be within [1, 256].
+//
+// Notice that in-flight shakes are measured in [TicksDuration], so
+// changing the tick rate while shakes are active will change their
+// real-time length. If you want ongoing effects to keep their wall-clock
+// timing, use [AccessorTick.SetRateRescaled]() instead.
+//
 // [ticks and updates]: https://github.com/edwinsyarief/mipix/blob/main/docs/ups-vs-tps.md
 func (AccessorTick) SetRate(tickRate int) {
 	pkgController.tickSetRate(tickRate)
 }
 
+// Like [AccessorTick.SetRate](), but also rescales the elapsed
+// time, fades and durations of active shakes so they preserve
+// their wall-clock timing. Indefinite shakes remain indefinite.
+func (AccessorTick) SetRateRescaled(tickRate int) {
+	pkgController.tickSetRateRescaled(tickRate)
+}
+
+// Enables or disables manual stepping. With manual stepping, mipix
+// no longer advances the current tick and updates the camera on its
+// own after each [Game].Update(). Instead, you must call [AccessorTick.Step]()
+// whenever you want the simulation to advance, which gives you full
+// control over the simulation cadence, as needed in lockstep or
+// deterministic multiplayer games. Disabled by default.
+func (AccessorTick) SetManualStepping(manual bool) {
+	pkgController.tickSetManualStepping(manual)
+}
+
+// Returns whether manual stepping is enabled.
+// See [AccessorTick.SetManualStepping]() for more details.
+func (AccessorTick) IsManualStepping() bool {
+	return pkgController.tickIsManualStepping()
+}
+
+// Advances the current tick by the tick rate and updates the camera,
+// shakes, fades and other tick-based effects exactly once. Can only be
+// used with manual stepping enabled, and never during [Game].Draw().
+// See [AccessorTick.SetManualStepping]() for more details.
+func (AccessorTick) Step() {
+	pkgController.tickStep()
+}
+
 // Returns the current tick rate. Defaults to 1.
 // See [AccessorTick.SetRate]() for more context.
 func (AccessorTick) GetRate() int {
@@ -0,0 +1,55 @@
+package mipix
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// See [Cursor]().
+type AccessorCursor struct{}
+
+// Provides access to the built-in pixel-perfect cursor in a
+// structured manner. Use through method chaining, e.g.:
+//
+//	mipix.Cursor().SetImage(cursorImg, 0, 0)
+//	mipix.Cursor().SetSystemCursorHidden(true)
+//
+// System cursors often clash with pixel art. The built-in cursor
+// is drawn at logical resolution, snapped to the logical pixel grid,
+// and follows the camera zoom like any other logical content.
+func Cursor() AccessorCursor {
+	return AccessorCursor{}
+}
+
+// Sets the image for the built-in cursor. The hotspot is the point
+// of the image, in logical pixels, that will be placed at the cursor
+// position. Passing a nil image disables the built-in cursor.
+//
+// The cursor is drawn at the end of the frame, after overlays and
+// before debug info.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorCursor) SetImage(img *ebiten.Image, hotspotX, hotspotY int) {
+	pkgController.cursorSetImage(img, hotspotX, hotspotY)
+}
+
+// Returns the current cursor image and hotspot, if any.
+func (AccessorCursor) GetImage() (img *ebiten.Image, hotspotX, hotspotY int) {
+	return pkgController.cursorGetImage()
+}
+
+// Hides or shows the system cursor. This is only a shortcut for
+// [ebiten.SetCursorMode]() with [ebiten.CursorModeHidden] or
+// [ebiten.CursorModeVisible], but it's typically used together
+// with [AccessorCursor.SetImage]().
+func (AccessorCursor) SetSystemCursorHidden(hidden bool) {
+	if hidden {
+		ebiten.SetCursorMode(ebiten.CursorModeHidden)
+	} else {
+		ebiten.SetCursorMode(ebiten.CursorModeVisible)
+	}
+}
+
+// Returns the global logical coordinates of the built-in cursor,
+// already snapped to the logical pixel grid. The position is updated
+// after each [Game].Update().
+func (AccessorCursor) Position() (x, y int) {
+	return pkgController.cursorX, pkgController.cursorY
+}
@@ -24,6 +24,7 @@ type Offscreen struct {
 	width         int
 	height        int
 	drawImageOpts ebiten.DrawImageOptions
+	clearColor    color.Color
 }
 
 // Creates a new offscreen with the given logical size.
@@ -71,9 +72,23 @@ func (self *Offscreen) CoatRect(bounds image.Rectangle, fillColor color.Color) {
 	internal.FillOverRect(self.canvas, bounds, fillColor)
 }
 
-// Clears the underlying offscreen canvas.
+// Clears the underlying offscreen canvas. If a clear color has
+// been set, the canvas is filled with it instead of becoming
+// transparent. See [Offscreen.SetClearColor]().
 func (self *Offscreen) Clear() {
-	self.canvas.Clear()
+	if self.clearColor == nil {
+		self.canvas.Clear()
+	} else {
+		self.canvas.Fill(self.clearColor)
+	}
+}
+
+// Sets the color that [Offscreen.Clear]() fills the canvas with.
+// For example, light accumulation buffers will typically want to
+// clear to black. Passing nil restores the default transparent
+// clear.
+func (self *Offscreen) SetClearColor(clearColor color.Color) {
+	self.clearColor = clearColor
 }
 
 // Projects the offscreen into the given target. In most cases,
@@ -1,5 +1,7 @@
 package mipix
 
+import "image"
+
 // See [Redraw]().
 type AccessorRedraw struct{}
 
@@ -55,6 +57,19 @@ func (AccessorRedraw) Request() {
 	pkgController.redrawRequest()
 }
 
+// Like [AccessorRedraw.Request](), but indicating which area of
+// the game changed, in logical world coordinates. The whole frame
+// is still redrawn, but the areas are tracked individually so
+// [AccessorDebug.DrawRedrawHeatmap]() can show which regions of
+// the screen are being redrawn most often.
+//
+// Empty areas are ignored.
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorRedraw) RequestArea(area image.Rectangle) {
+	pkgController.redrawRequestArea(area)
+}
+
 // Returns whether a redraw is still pending. Notice that
 // besides explicit requests, a redraw can also be pending
 // due to a canvas resize, the modification of the scaling
@@ -75,3 +90,17 @@ func (AccessorRedraw) Pending() bool {
 func (AccessorRedraw) ScheduleClear() {
 	pkgController.redrawScheduleClear()
 }
+
+// Schedules a clear and requests a redraw for the next [Game].Draw().
+// This is the combo you want when swapping scenes, as otherwise
+// stale contents from the previous scene could remain visible
+// for a frame on areas the new scene doesn't draw over (especially
+// with managed redraws and [ebiten.SetScreenClearedEveryFrame](false)).
+//
+// Equivalent to calling both [AccessorRedraw.ScheduleClear]() and
+// [AccessorRedraw.Request]().
+//
+// Must only be called during initialization or [Game].Update().
+func (AccessorRedraw) BeginSceneChange() {
+	pkgController.redrawBeginSceneChange()
+}
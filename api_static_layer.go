@@ -0,0 +1,68 @@
+package mipix
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Static layers are offscreens that cache their contents until
+// invalidated. They formalize a common optimization for static
+// backgrounds and other camera-independent elements that are
+// expensive to render: you provide a render function that is
+// only invoked when the layer is dirty, and then project the
+// cached result as many times as needed.
+//
+// Static layers are automatically invalidated on layout and
+// resolution changes. Otherwise, you must call
+// [StaticLayer.Invalidate]() whenever the contents need to
+// be rendered again.
+//
+// Like offscreens, static layers should be created once and
+// reused, never per frame.
+type StaticLayer struct {
+	offscreen        *Offscreen
+	dirty            bool
+	layoutGeneration uint64
+}
+
+// Creates a new static layer with the given logical size.
+// The layer starts dirty.
+func NewStaticLayer(width, height int) *StaticLayer {
+	return &StaticLayer{
+		offscreen:        NewOffscreen(width, height),
+		dirty:            true,
+		layoutGeneration: pkgController.layoutGeneration,
+	}
+}
+
+// Returns the size of the static layer.
+func (self *StaticLayer) Size() (width, height int) {
+	return self.offscreen.Size()
+}
+
+// Marks the layer as dirty, so the next [StaticLayer.Render]()
+// will invoke its render function again.
+func (self *StaticLayer) Invalidate() {
+	self.dirty = true
+}
+
+// Returns whether the layer needs to be rendered again.
+func (self *StaticLayer) IsDirty() bool {
+	return self.dirty || self.layoutGeneration != pkgController.layoutGeneration
+}
+
+// Clears the layer and invokes the given function to render
+// its contents, but only if the layer is dirty. Otherwise, the
+// call is a no-op and the cached contents are preserved.
+func (self *StaticLayer) Render(renderFunc func(canvas *ebiten.Image)) {
+	if !self.IsDirty() {
+		return
+	}
+	self.offscreen.Clear()
+	renderFunc(self.offscreen.Target())
+	self.dirty = false
+	self.layoutGeneration = pkgController.layoutGeneration
+}
+
+// Projects the cached layer contents into the given target.
+// See [Offscreen.Project]() for more details.
+func (self *StaticLayer) Project(target *ebiten.Image) {
+	self.offscreen.Project(target)
+}
@@ -0,0 +1,116 @@
+package mipix
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed filters/bloom.kage
+var _bloom []byte
+
+func (self *controller) scalingSetBloom(threshold, intensity float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change bloom during draw stage")
+		return
+	}
+	if threshold < 0.0 || threshold > 1.0 {
+		self.reportMisuse("bloom threshold must be in [0, 1] range")
+		return
+	}
+	if intensity < 0.0 {
+		self.reportMisuse("bloom intensity can't be negative")
+		return
+	}
+	if threshold != self.bloomThreshold || intensity != self.bloomIntensity {
+		self.needsRedraw = true
+		self.bloomThreshold, self.bloomIntensity = threshold, intensity
+	}
+	if intensity > 0.0 && self.bloomShader == nil && !self.bloomFailed {
+		self.compileBloomShader()
+	}
+}
+
+func (self *controller) scalingGetBloom() (threshold, intensity float64) {
+	return self.bloomThreshold, self.bloomIntensity
+}
+
+// Compiles the bloom shader. If compilation fails and a shader
+// error handler has been set, the handler is notified and bloom
+// is disabled instead of panicking.
+func (self *controller) compileBloomShader() {
+	var err error
+	self.bloomShader, err = ebiten.NewShader(_bloom)
+	if err != nil {
+		if self.shaderErrorHandler == nil {
+			panic("Failed to compile bloom shader: " + err.Error())
+		}
+		self.bloomFailed = true
+		self.needsRedraw = true
+		self.shaderErrorHandler(self.scalingFilter, fmt.Errorf("bloom shader: %w", err))
+		return
+	}
+	self.bloomOpts.Blend = ebiten.BlendLighter
+	self.bloomOpts.Uniforms = make(map[string]interface{}, 3)
+}
+
+// Applies the bloom post effect to the given target, which is
+// expected to be the active high resolution canvas after the
+// final projection.
+func (self *controller) applyBloom(target *ebiten.Image) {
+	if self.bloomIntensity <= 0.0 || self.bloomFailed {
+		return
+	}
+	if self.bloomShader == nil {
+		self.compileBloomShader()
+		if self.bloomFailed {
+			return
+		}
+	}
+	if self.shaderOpts.Uniforms == nil {
+		self.initShaderProperties()
+	}
+
+	// copy target contents to the bloom buffer
+	bounds := target.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if self.bloomBuffer == nil || self.bloomBuffer.Bounds().Dx() != width || self.bloomBuffer.Bounds().Dy() != height {
+		if self.bloomBuffer != nil {
+			self.bloomBuffer.Deallocate()
+		}
+		self.bloomBuffer = ebiten.NewImage(width, height)
+	}
+	var copyOpts ebiten.DrawImageOptions
+	copyOpts.GeoM.Translate(-float64(bounds.Min.X), -float64(bounds.Min.Y))
+	copyOpts.Blend = ebiten.BlendCopy
+	self.bloomBuffer.DrawImage(target, &copyOpts)
+
+	// set up vertices
+	self.shaderVertices[0].DstX = float32(bounds.Min.X)
+	self.shaderVertices[0].DstY = float32(bounds.Min.Y)
+	self.shaderVertices[1].DstX = float32(bounds.Max.X)
+	self.shaderVertices[1].DstY = self.shaderVertices[0].DstY
+	self.shaderVertices[2].DstX = self.shaderVertices[1].DstX
+	self.shaderVertices[2].DstY = float32(bounds.Max.Y)
+	self.shaderVertices[3].DstX = self.shaderVertices[0].DstX
+	self.shaderVertices[3].DstY = self.shaderVertices[2].DstY
+
+	self.shaderVertices[0].SrcX = 0
+	self.shaderVertices[0].SrcY = 0
+	self.shaderVertices[1].SrcX = float32(width)
+	self.shaderVertices[1].SrcY = 0
+	self.shaderVertices[2].SrcX = float32(width)
+	self.shaderVertices[2].SrcY = float32(height)
+	self.shaderVertices[3].SrcX = 0
+	self.shaderVertices[3].SrcY = float32(height)
+
+	// the blur spread is half a logical pixel in high resolution
+	minX, _, maxX, _ := self.cameraAreaF64()
+	self.bloomOpts.Images[0] = self.bloomBuffer
+	self.bloomOpts.Uniforms["Threshold"] = float32(self.bloomThreshold)
+	self.bloomOpts.Uniforms["Intensity"] = float32(self.bloomIntensity)
+	self.bloomOpts.Uniforms["Spread"] = float32(0.5 * float64(width) / (maxX - minX))
+	target.DrawTrianglesShader(self.shaderVertices, self.shaderVertIndices, self.bloomShader, &self.bloomOpts)
+	self.bloomOpts.Images[0] = nil
+}
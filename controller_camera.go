@@ -4,70 +4,312 @@ import (
 	"image"
 	"math"
 
+	ebimath "github.com/edwinsyarief/ebi-math"
 	"github.com/edwinsyarief/mipix/internal"
 	"github.com/edwinsyarief/mipix/shaker"
 	"github.com/edwinsyarief/mipix/tracker"
 	"github.com/edwinsyarief/mipix/zoomer"
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
 func (self *controller) cameraAreaGet() image.Rectangle {
 	return self.cameraArea
 }
 
+func (self *controller) cameraPreviousArea() image.Rectangle {
+	return self.cameraPrevArea
+}
+
+func (self *controller) cameraVisibleWorldBounds(margin float64) (minX, minY, maxX, maxY float64) {
+	minX, minY, maxX, maxY = self.cameraAreaF64()
+	return minX - margin, minY - margin, maxX + margin, maxY + margin
+}
+
 func (self *controller) cameraAreaF64() (minX, minY, maxX, maxY float64) {
-	zoomedWidth := float64(self.logicalWidth) / self.zoomCurrent
-	zoomedHeight := float64(self.logicalHeight) / self.zoomCurrent
-	if self.stretchingEnabled && self.keepAspectRatio {
+	if self.renderViewActive {
+		area := self.renderViewArea
+		return area[0], area[1], area[2], area[3]
+	}
+	zoom := self.effectiveZoom()
+	zoomedWidth := float64(self.logicalWidth) / zoom
+	zoomedHeight := float64(self.logicalHeight) / zoom
+	if self.stretchingEnabled && self.keepAspectRatio && !self.fixedLogicalCanvas && self.hiResWidth > 0 && self.hiResHeight > 0 {
+		hiResWidth, hiResHeight := self.hiResWidth, self.hiResHeight
+		if self.orientationSwapsAxes() {
+			hiResWidth, hiResHeight = hiResHeight, hiResWidth
+		}
 		scale := internal.BestFitFloat(
 			self.dynamicScaling,
-			self.hiResWidth,
-			self.hiResHeight,
+			hiResWidth,
+			hiResHeight,
 			self.bestFitRenderSize.X,
 			&self.bestFitRenderSize.Y,
 			&self.bestFitContextSize.X,
 			&self.bestFitContextSize.Y, true)
 
-		zoomedWidth = float64(self.hiResWidth) / scale / self.zoomCurrent
-		zoomedHeight = float64(self.hiResHeight) / scale / self.zoomCurrent
+		zoomedWidth = float64(hiResWidth) / scale / zoom
+		zoomedHeight = float64(hiResHeight) / scale / zoom
+	}
+	if self.fixedLogicalCanvas {
+		canvasMinX, canvasMinY := self.fixedCanvasOrigin()
+		centerX := float64(canvasMinX) + float64(self.logicalWidth)/2.0
+		centerY := float64(canvasMinY) + float64(self.logicalHeight)/2.0
+		minX, minY = centerX-zoomedWidth/2.0, centerY-zoomedHeight/2.0
+		return minX, minY, minX + zoomedWidth, minY + zoomedHeight
+	}
+	centerX := self.trackerCurrentX + self.shakerOffsetX
+	centerY := self.trackerCurrentY + self.shakerOffsetY
+	if self.hybridPixelMode {
+		centerX, centerY = math.Floor(centerX), math.Floor(centerY)
+	}
+	centerX, centerY = self.clampCenterToBounds(centerX, centerY, zoomedWidth, zoomedHeight)
+	minX = centerX - zoomedWidth/2.0
+	minY = centerY - zoomedHeight/2.0
+	if self.tightCanvas {
+		minX, minY = math.Round(minX), math.Round(minY)
 	}
-	minX = self.trackerCurrentX - zoomedWidth/2.0 + self.shakerOffsetX
-	minY = self.trackerCurrentY - zoomedHeight/2.0 + self.shakerOffsetY
 	return minX, minY, minX + zoomedWidth, minY + zoomedHeight
 }
 
 func (self *controller) updateCameraArea() {
-	minX, minY, maxX, maxY := self.cameraAreaF64()
-	self.cameraArea = image.Rect(
-		int(math.Floor(minX)), int(math.Floor(minY)),
-		int(math.Ceil(maxX)), int(math.Ceil(maxY)),
-	)
+	prevArea := self.cameraArea
+	if self.fixedLogicalCanvas {
+		minX, minY := self.fixedCanvasOrigin()
+		self.cameraArea = image.Rect(minX, minY, minX+self.logicalWidth, minY+self.logicalHeight)
+	} else {
+		minX, minY, maxX, maxY := self.cameraAreaF64()
+		self.cameraArea = image.Rect(
+			int(math.Floor(minX)), int(math.Floor(minY)),
+			int(math.Ceil(maxX)), int(math.Ceil(maxY)),
+		)
+	}
 	internal.BridgedCameraOrigin = self.cameraArea.Min
+	if self.areaChangedHandler != nil && self.cameraArea != prevArea {
+		self.areaChangedHandler(prevArea, self.cameraArea)
+	}
+}
+
+func (self *controller) cameraOnAreaChanged(handler func(old, new image.Rectangle)) {
+	if self.inDraw {
+		self.reportStageMisuse("can't OnAreaChanged during draw stage")
+		return
+	}
+	self.areaChangedHandler = handler
+}
+
+// Returns the zoom level used to compute the camera area, including
+// zoom pulses. With a fixed logical canvas, zooming out is not possible.
+func (self *controller) effectiveZoom() float64 {
+	zoom := self.zoomCurrent * (1.0 + self.zoomPulseOffset())
+	if self.fixedLogicalCanvas {
+		return max(zoom, 1.0)
+	}
+	return zoom
+}
+
+// Returns the logical coordinates of the top-left corner of the
+// fixed logical canvas. The camera focus is snapped so the canvas
+// is always aligned to the logical pixel grid.
+func (self *controller) fixedCanvasOrigin() (int, int) {
+	centerX := self.trackerCurrentX + self.shakerOffsetX
+	centerY := self.trackerCurrentY + self.shakerOffsetY
+	zoom := self.effectiveZoom()
+	centerX, centerY = self.clampCenterToBounds(centerX, centerY, float64(self.logicalWidth)/zoom, float64(self.logicalHeight)/zoom)
+	minX := math.Round(centerX - float64(self.logicalWidth)/2.0)
+	minY := math.Round(centerY - float64(self.logicalHeight)/2.0)
+	return int(minX), int(minY)
+}
+
+// Clamps the given camera center so an area of the given size
+// remains within the camera bounds, if any. Axes where the bounds
+// are smaller than the area are centered instead.
+func (self *controller) clampCenterToBounds(centerX, centerY, width, height float64) (float64, float64) {
+	if !self.hasBounds {
+		return centerX, centerY
+	}
+	clampAxis := func(center, size, boundsMin, boundsMax float64) float64 {
+		if size >= boundsMax-boundsMin {
+			return (boundsMin + boundsMax) / 2.0
+		}
+		return ebimath.Clamp(center, boundsMin+size/2.0, boundsMax-size/2.0)
+	}
+	centerX = clampAxis(centerX, width, self.bounds[0], self.bounds[2])
+	centerY = clampAxis(centerY, height, self.bounds[1], self.bounds[3])
+	return centerX, centerY
+}
+
+func (self *controller) cameraSetBounds(minX, minY, maxX, maxY float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't set camera bounds during draw stage")
+		return
+	}
+	if math.IsNaN(minX) || math.IsNaN(minY) || math.IsNaN(maxX) || math.IsNaN(maxY) {
+		self.reportMisuse("camera bounds can't be NaN")
+		return
+	}
+	if minX > maxX || minY > maxY {
+		self.reportMisuse("camera bounds can't be inverted")
+		return
+	}
+	self.hasBounds = true
+	self.bounds = [4]float64{minX, minY, maxX, maxY}
+	self.needsRedraw = true
+	self.updateCameraArea()
+}
+
+func (self *controller) cameraClearBounds() {
+	if self.inDraw {
+		self.reportStageMisuse("can't clear camera bounds during draw stage")
+		return
+	}
+	if self.hasBounds {
+		self.hasBounds = false
+		self.needsRedraw = true
+		self.updateCameraArea()
+	}
+}
+
+func (self *controller) cameraGetBounds() (minX, minY, maxX, maxY float64, ok bool) {
+	if !self.hasBounds {
+		return 0, 0, 0, 0, false
+	}
+	return self.bounds[0], self.bounds[1], self.bounds[2], self.bounds[3], true
 }
 
 // ---- tracking ----
 
+func (self *controller) cameraSetHybridPixelMode(enabled bool) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change hybrid pixel mode during draw stage")
+		return
+	}
+	if enabled != self.hybridPixelMode {
+		self.needsRedraw = true
+		self.hybridPixelMode = enabled
+		self.updateCameraArea()
+	}
+}
+
+func (self *controller) cameraIsHybridPixelMode() bool {
+	return self.hybridPixelMode
+}
+
 func (self *controller) cameraGetTracker() tracker.Tracker {
 	return self.tracker
 }
 
 func (self *controller) cameraSetTracker(tracker tracker.Tracker) {
 	if self.inDraw {
-		panic("can't set tracker during draw stage")
+		self.reportStageMisuse("can't set tracker during draw stage")
+		return
 	}
 	self.tracker = tracker
 }
 
+func (self *controller) cameraSetTrackerSmooth(newTracker tracker.Tracker) {
+	if self.inDraw {
+		self.reportStageMisuse("can't set tracker during draw stage")
+		return
+	}
+	self.tracker = newTracker
+	if seeder, ok := newTracker.(tracker.Seeder); ok {
+		seeder.Seed(self.trackerPrevSpeedX, self.trackerPrevSpeedY)
+	}
+}
+
 func (self *controller) cameraNotifyCoordinates(x, y float64) {
 	if self.inDraw {
-		panic("can't notify tracking coordinates during draw stage")
+		self.reportStageMisuse("can't notify tracking coordinates during draw stage")
+		return
 	}
 	self.trackerTargetX, self.trackerTargetY = x, y
+	self.zoomAnchor.active = false
+}
+
+func (self *controller) cameraPan(dirX, dirY, speedPerSecond float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't pan camera during draw stage")
+		return
+	}
+	if speedPerSecond < 0.0 {
+		self.reportMisuse("pan speed can't be negative")
+		return
+	}
+
+	// normalize direction, preserving analog magnitudes below 1
+	length := math.Hypot(dirX, dirY)
+	if length == 0.0 {
+		return
+	}
+	if length > 1.0 {
+		dirX, dirY = dirX/length, dirY/length
+	}
+
+	// keep the target within the camera bounds, so panning
+	// doesn't accumulate beyond the edges
+	advance := speedPerSecond / float64(internal.GetUPS())
+	targetX, targetY := self.trackerTargetX+dirX*advance, self.trackerTargetY+dirY*advance
+	minX, minY, maxX, maxY := self.cameraAreaF64()
+	targetX, targetY = self.clampCenterToBounds(targetX, targetY, maxX-minX, maxY-minY)
+	self.cameraNotifyCoordinates(targetX, targetY)
+}
+
+func (self *controller) cameraNotifyDelta(dx, dy float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't notify tracking coordinates during draw stage")
+		return
+	}
+	self.trackerTargetX += dx
+	self.trackerTargetY += dy
+	self.zoomAnchor.active = false
+}
+
+func (self *controller) cameraSetScrollWindow(mode ScrollMode, leftThreshold, rightThreshold float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't set scroll window during draw stage")
+		return
+	}
+	if mode >= scrollModeEndSentinel {
+		self.reportMisuse("invalid scroll mode")
+		return
+	}
+	if leftThreshold < 0.0 || rightThreshold > 1.0 || leftThreshold > rightThreshold {
+		self.reportMisuse("scroll window thresholds must satisfy 0 <= left <= right <= 1")
+		return
+	}
+	if self.scrollMode == ScrollDisabled && mode != ScrollDisabled {
+		self.scrollCenterX = self.trackerCurrentX
+	}
+	self.scrollMode = mode
+	self.scrollLeft, self.scrollRight = leftThreshold, rightThreshold
+}
+
+// Returns the horizontal target to be used by the tracker,
+// taking the scroll window into account.
+func (self *controller) getScrollTargetX() float64 {
+	if self.scrollMode == ScrollDisabled {
+		return self.trackerTargetX
+	}
+
+	minX, _, maxX, _ := self.cameraAreaF64()
+	viewWidth := maxX - minX
+	viewLeft := self.scrollCenterX - viewWidth/2.0
+	windowMinX := viewLeft + self.scrollLeft*viewWidth
+	windowMaxX := viewLeft + self.scrollRight*viewWidth
+	if self.trackerTargetX > windowMaxX {
+		self.scrollCenterX += self.trackerTargetX - windowMaxX
+	} else if self.trackerTargetX < windowMinX && self.scrollMode == ScrollWindow {
+		self.scrollCenterX -= windowMinX - self.trackerTargetX
+	}
+	return self.scrollCenterX
 }
 
 func (self *controller) cameraResetCoordinates(x, y float64) {
 	if self.inDraw {
-		panic("can't reset camera coordinates during draw stage")
+		self.reportStageMisuse("can't reset camera coordinates during draw stage")
+		return
 	}
+	self.scrollCenterX = x
 	self.trackerTargetX, self.trackerTargetY = x, y
 	self.trackerCurrentX, self.trackerCurrentY = x, y
 	if self.redrawManaged && (x != self.trackerCurrentX || y != self.trackerCurrentY) {
@@ -76,27 +318,65 @@ func (self *controller) cameraResetCoordinates(x, y float64) {
 	self.updateCameraArea()
 }
 
+func (self *controller) cameraPrime() {
+	if self.inDraw {
+		self.reportStageMisuse("can't Prime camera during draw stage")
+		return
+	}
+	if self.logicalWidth == 0 || self.logicalHeight == 0 {
+		self.reportMisuse("can't Prime camera before setting the game resolution")
+		return
+	}
+
+	// commit targets as current values
+	self.cameraZoomReset(self.zoomTarget)
+	self.scrollCenterX = self.trackerTargetX
+	self.trackerCurrentX, self.trackerCurrentY = self.trackerTargetX, self.trackerTargetY
+	self.trackerPrevSpeedX, self.trackerPrevSpeedY = 0.0, 0.0
+	self.applyConstraint()
+	self.updateCameraArea()
+	self.cameraPrevArea = self.cameraArea
+	self.needsRedraw = true
+}
+
 func (self *controller) cameraFlushCoordinates() {
 	if self.lastFlushCoordinatesTick == self.currentTick {
 		return
 	}
 	self.lastFlushCoordinatesTick = self.currentTick
+	self.cameraPrevArea = self.cameraArea
+	self.updateFocusPull()
 	self.updateZoom()
-	self.updateTracking()
+	self.updateZoomAnchor()
+	self.updateFollowTargets()
+	if !self.trackingDisabled {
+		self.updateTracking()
+	}
+	self.finishFocusPull()
+	self.applyConstraint()
 	self.updateShake()
 	self.updateCameraArea()
 }
 
 func (self *controller) updateTracking() {
+	// carry the camera along the reference frame first, so
+	// trackers only need to deal with the relative motion
+	updateDelta := 1.0 / float64(Tick().UPS())
+	if self.frameVelocityX != 0 || self.frameVelocityY != 0 {
+		self.trackerCurrentX += self.frameVelocityX * updateDelta
+		self.trackerCurrentY += self.frameVelocityY * updateDelta
+		self.needsRedraw = true
+	}
+
 	camTracker := self.cameraGetInternalTracker()
 	changeX, changeY := camTracker.Update(
 		self.trackerCurrentX, self.trackerCurrentY,
-		self.trackerTargetX, self.trackerTargetY,
+		self.getScrollTargetX(), self.trackerTargetY,
 		self.trackerPrevSpeedX, self.trackerPrevSpeedY,
 	)
 	self.trackerCurrentX += changeX
 	self.trackerCurrentY += changeY
-	updateDelta := 1.0 / float64(Tick().UPS())
+	self.updateSweep()
 	self.trackerPrevSpeedX = changeX / updateDelta
 	self.trackerPrevSpeedY = changeY / updateDelta
 
@@ -105,7 +385,99 @@ func (self *controller) updateTracking() {
 	}
 }
 
+func (self *controller) cameraSetFollowTargets(points func() []ebimath.Vector, selector func(points []ebimath.Vector) ebimath.Vector) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetFollowTargets during draw stage")
+		return
+	}
+	self.followPoints = points
+	self.followSelector = selector
+}
+
+func (self *controller) updateFollowTargets() {
+	if self.followPoints == nil {
+		return
+	}
+	points := self.followPoints()
+	if len(points) == 0 {
+		return
+	}
+	if self.followSelector != nil {
+		target := self.followSelector(points)
+		self.trackerTargetX, self.trackerTargetY = target.X, target.Y
+		return
+	}
+
+	// default selector: nearest point to the camera
+	nearest, nearestDist := points[0], math.Inf(1)
+	for _, point := range points {
+		dist := math.Hypot(point.X-self.trackerCurrentX, point.Y-self.trackerCurrentY)
+		if dist < nearestDist {
+			nearest, nearestDist = point, dist
+		}
+	}
+	self.trackerTargetX, self.trackerTargetY = nearest.X, nearest.Y
+}
+
+func (self *controller) cameraSetConstraint(constraint func(x, y float64) (float64, float64)) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetConstraint during draw stage")
+		return
+	}
+	self.constraint = constraint
+}
+
+// Remaps the camera focus with the user constraint, if any.
+// Called after tracking, before shakes and camera area updates.
+func (self *controller) applyConstraint() {
+	if self.constraint == nil {
+		return
+	}
+	x, y := self.constraint(self.trackerCurrentX, self.trackerCurrentY)
+	if x != self.trackerCurrentX || y != self.trackerCurrentY {
+		self.trackerCurrentX, self.trackerCurrentY = x, y
+		self.needsRedraw = true
+	}
+}
+
+func (self *controller) cameraSetTrackingEnabled(enabled bool) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change tracking state during draw stage")
+		return
+	}
+	self.trackingDisabled = !enabled
+	if self.trackingDisabled {
+		self.trackerPrevSpeedX, self.trackerPrevSpeedY = 0.0, 0.0
+	}
+}
+
+func (self *controller) cameraIsTrackingEnabled() bool {
+	return !self.trackingDisabled
+}
+
+func (self *controller) cameraSpeed() (float64, float64) {
+	return self.trackerPrevSpeedX, self.trackerPrevSpeedY
+}
+
+func (self *controller) cameraSetReferenceFrameVelocity(vx, vy float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetReferenceFrameVelocity during draw stage")
+		return
+	}
+	if math.IsNaN(vx) || math.IsNaN(vy) || math.IsInf(vx, 0) || math.IsInf(vy, 0) {
+		self.reportMisuse("reference frame velocity must be finite")
+		return
+	}
+	self.frameVelocityX, self.frameVelocityY = vx, vy
+}
+
 func (self *controller) cameraGetInternalTracker() tracker.Tracker {
+	if self.focus.active {
+		return focusPullTracker{&self.focus}
+	}
+	if self.sweep.active {
+		return &self.sweep
+	}
 	if self.tracker != nil {
 		return self.tracker
 	}
@@ -123,20 +495,70 @@ func (self *controller) updateZoom() {
 	zoomer := self.cameraGetInternalZoomer()
 	change := zoomer.Update(self.zoomCurrent, self.zoomTarget)
 	if math.IsNaN(change) {
-		panic("zoomer returned NaN")
+		if self.zoomSafetyPolicy != ZoomSafetyClamp {
+			panic("zoomer returned NaN")
+		}
+		change = 0.0
 	}
 	self.zoomCurrent += change
-	internal.CurrentZoom = self.zoomCurrent
 	if self.zoomCurrent < 0.005 || self.zoomCurrent > 500.0 {
-		panic("something is wrong with the zoomer: after last update, zoom went outside [0.005, 500.0]")
+		if self.zoomSafetyPolicy != ZoomSafetyClamp {
+			panic("something is wrong with the zoomer: after last update, zoom went outside [0.005, 500.0]")
+		}
+		self.zoomCurrent = ebimath.Clamp(self.zoomCurrent, 0.005, 500.0)
+		self.zoomAnchor.active = false // the target can't be reached
 	}
+	internal.CurrentZoom = self.zoomCurrent
 
 	if self.redrawManaged && change != 0 {
 		self.needsRedraw = true
 	}
+
+	// advance zoom pulse
+	if self.zoomPulseElapsed < self.zoomPulseDuration {
+		self.zoomPulseElapsed = min(self.zoomPulseElapsed+TicksDuration(self.tickRate), self.zoomPulseDuration)
+		self.needsRedraw = true
+	}
+}
+
+func (self *controller) cameraSetZoomSafetyPolicy(policy ZoomSafetyPolicy) {
+	if policy >= zoomSafetyPolicyEndSentinel {
+		self.reportMisuse("invalid ZoomSafetyPolicy")
+		return
+	}
+	self.zoomSafetyPolicy = policy
+}
+
+func (self *controller) cameraTriggerZoomPulse(magnitude float64, duration TicksDuration) {
+	if self.inDraw {
+		self.reportStageMisuse("can't TriggerZoomPulse during draw stage")
+		return
+	}
+	if magnitude <= -1.0 || magnitude > 10.0 || math.IsNaN(magnitude) {
+		self.reportMisuse("zoom pulse magnitude must be in (-1, 10] range")
+		return
+	}
+	self.zoomPulseMagnitude = magnitude
+	self.zoomPulseElapsed = 0
+	self.zoomPulseDuration = duration
+}
+
+// Returns the relative zoom offset caused by the current zoom pulse.
+// The pulse is a decaying oscillation: it punches in quickly, slightly
+// overshoots on the way back and settles down by the end.
+func (self *controller) zoomPulseOffset() float64 {
+	if self.zoomPulseElapsed >= self.zoomPulseDuration {
+		return 0.0
+	}
+	t := float64(self.zoomPulseElapsed) / float64(self.zoomPulseDuration)
+	decay := (1.0 - t) * (1.0 - t)
+	return self.zoomPulseMagnitude * math.Sin(3.0*math.Pi*t) * decay
 }
 
 func (self *controller) cameraGetInternalZoomer() zoomer.Zoomer {
+	if self.focus.active {
+		return focusPullZoomer{&self.focus}
+	}
 	if self.zoomer != nil {
 		return self.zoomer
 	}
@@ -148,36 +570,184 @@ func (self *controller) cameraGetInternalZoomer() zoomer.Zoomer {
 }
 
 func (self *controller) updateShake() {
+	if self.shakeFrozen {
+		return // hold current offsets
+	}
+
 	// compute new offsets
-	var offsetX, offsetY float64
+	var offsetX, offsetY, rotation float64
 	for i := range self.shakerChannels {
-		self.shakerChannels[i].Update(i, self.tickRate)
-		offsetX += self.shakerChannels[i].offsetX
-		offsetY += self.shakerChannels[i].offsetY
+		terminated := self.shakerChannels[i].Update(self.shakerChannelFallback(i), self.tickRate)
+		if terminated && i < len(self.shakeEndHandlers) && self.shakeEndHandlers[i] != nil {
+			self.shakeEndHandlers[i]()
+		}
+		if !self.shakerChannels[i].maskX {
+			offsetX += self.shakerChannels[i].offsetX
+		}
+		if !self.shakerChannels[i].maskY {
+			offsetY += self.shakerChannels[i].offsetY
+		}
+		rotation += self.shakerChannels[i].rotation
 	}
+	traumaOffsetX, traumaOffsetY := self.updateTrauma()
+	offsetX += traumaOffsetX
+	offsetY += traumaOffsetY
+	recoilOffsetX, recoilOffsetY := self.updateRecoil()
+	offsetX += recoilOffsetX
+	offsetY += recoilOffsetY
 
 	// set needsRedraw flag if necessary
-	if self.redrawManaged && (offsetX != self.shakerOffsetX || offsetY != self.shakerOffsetY) {
+	if self.redrawManaged && (offsetX != self.shakerOffsetX || offsetY != self.shakerOffsetY || rotation != self.shakerRotation) {
 		self.needsRedraw = true
 	}
 
 	// register new offsets
 	self.shakerOffsetX = offsetX
 	self.shakerOffsetY = offsetY
+	self.shakerRotation = rotation
 }
 
 func (self *controller) cameraZoom(newZoomLevel float64) {
 	if self.inDraw {
-		panic("can't zoom during draw stage")
+		self.reportStageMisuse("can't zoom during draw stage")
+		return
+	}
+	self.zoomTarget = ebimath.Clamp(newZoomLevel, self.zoomLimitMin, self.zoomLimitMax)
+	self.zoomAnchor.active = false
+}
+
+func (self *controller) cameraSetZoomLimits(minZoom, maxZoom float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't set zoom limits during draw stage")
+		return
+	}
+	if math.IsNaN(minZoom) || math.IsNaN(maxZoom) || minZoom < 0.0 {
+		self.reportMisuse("zoom limits must be non-negative numbers")
+		return
+	}
+	if minZoom > maxZoom {
+		self.reportMisuse("min zoom limit can't be greater than max zoom limit")
+		return
+	}
+	self.zoomLimitMin, self.zoomLimitMax = minZoom, maxZoom
+	self.zoomTarget = ebimath.Clamp(self.zoomTarget, minZoom, maxZoom)
+}
+
+func (self *controller) cameraGetZoomLimits() (minZoom, maxZoom float64) {
+	return self.zoomLimitMin, self.zoomLimitMax
+}
+
+func (self *controller) cameraZoomTowards(newZoomLevel, worldX, worldY float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't zoom during draw stage")
+		return
+	}
+	self.cameraZoom(newZoomLevel)
+
+	// store the anchor's relative position within the current area
+	minX, minY, maxX, maxY := self.cameraAreaF64()
+	self.zoomAnchor = zoomAnchor{
+		active: true,
+		worldX: worldX,
+		worldY: worldY,
+		relX:   (worldX - minX) / (maxX - minX),
+		relY:   (worldY - minY) / (maxY - minY),
+	}
+}
+
+// Re-notifies the camera coordinates so the zoom anchor remains
+// at the same relative screen position. Must be called right after
+// updating the zoom, before tracking.
+func (self *controller) updateZoomAnchor() {
+	const RelativeTolerance = 1e-4 // many zoomers only approach the target asymptotically
+
+	if !self.zoomAnchor.active {
+		return
+	}
+	minX, minY, maxX, maxY := self.cameraAreaF64()
+	anchor := &self.zoomAnchor
+	self.trackerTargetX = anchor.worldX - (anchor.relX-0.5)*(maxX-minX)
+	self.trackerTargetY = anchor.worldY - (anchor.relY-0.5)*(maxY-minY)
+	if math.Abs(self.zoomCurrent-self.zoomTarget) <= self.zoomTarget*RelativeTolerance {
+		anchor.active = false
+	}
+}
+
+func (self *controller) cameraFitRect(minX, minY, maxX, maxY float64, padding float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't fit camera to rect during draw stage")
+		return
+	}
+	if maxX < minX || maxY < minY {
+		self.reportMisuse("invalid rect: max coordinates can't be smaller than min coordinates")
+		return
+	}
+	if padding < 0.0 {
+		self.reportMisuse("padding can't be negative")
+		return
+	}
+
+	zoom := self.zoomToFit(maxX-minX+padding*2.0, maxY-minY+padding*2.0)
+	self.cameraNotifyCoordinates((minX+maxX)/2.0, (minY+maxY)/2.0)
+	self.cameraZoom(zoom)
+}
+
+func (self *controller) cameraSetArea(minX, minY, maxX, maxY float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't set camera area during draw stage")
+		return
+	}
+	if maxX <= minX || maxY <= minY {
+		self.reportMisuse("invalid area: max coordinates must be greater than min coordinates")
+		return
 	}
-	self.zoomTarget = newZoomLevel
+
+	zoom := self.zoomToFit(maxX-minX, maxY-minY)
+	self.cameraZoomReset(zoom)
+	self.cameraResetCoordinates((minX+maxX)/2.0, (minY+maxY)/2.0)
+	self.needsRedraw = true
+}
+
+// Returns the zoom level required for a rect of the given
+// size to fit the viewport, clamped to [0.05, 500.0].
+func (self *controller) zoomToFit(rectWidth, rectHeight float64) float64 {
+	viewWidth, viewHeight := self.viewportSizeAtZoomOne()
+	zoom := 500.0
+	if rectWidth > 0.0 {
+		zoom = min(zoom, viewWidth/rectWidth)
+	}
+	if rectHeight > 0.0 {
+		zoom = min(zoom, viewHeight/rectHeight)
+	}
+	return ebimath.Clamp(zoom, 0.05, 500.0)
+}
+
+// Returns the size of the visible world area at zoom 1.0, in
+// logical pixels, taking stretching into account.
+func (self *controller) viewportSizeAtZoomOne() (width, height float64) {
+	areaMinX, areaMinY, areaMaxX, areaMaxY := self.cameraAreaF64()
+	return (areaMaxX - areaMinX) * self.effectiveZoom(), (areaMaxY - areaMinY) * self.effectiveZoom()
+}
+
+func (self *controller) cameraZoomForWorldSpan(worldWidth, worldHeight float64) float64 {
+	if worldWidth < 0.0 || worldHeight < 0.0 || (worldWidth == 0.0 && worldHeight == 0.0) {
+		self.reportMisuse("world span must be positive")
+		return self.zoomTarget
+	}
+	viewWidth, viewHeight := self.viewportSizeAtZoomOne()
+	if worldWidth > 0.0 {
+		return viewWidth / worldWidth
+	}
+	return viewHeight / worldHeight
 }
 
 func (self *controller) cameraZoomReset(zoomLevel float64) {
 	if self.inDraw {
-		panic("can't reset zoom during draw stage")
+		self.reportStageMisuse("can't reset zoom during draw stage")
+		return
 	}
 	self.zoomCurrent, self.zoomTarget, internal.CurrentZoom = zoomLevel, zoomLevel, zoomLevel
+	self.zoomAnchor.active = false
 	self.cameraGetInternalZoomer().Reset()
 }
 
@@ -187,7 +757,8 @@ func (self *controller) cameraGetZoomer() zoomer.Zoomer {
 
 func (self *controller) cameraSetZoomer(zoomer zoomer.Zoomer) {
 	if self.inDraw {
-		panic("can't change zoomer during draw stage")
+		self.reportStageMisuse("can't change zoomer during draw stage")
+		return
 	}
 	self.zoomer = zoomer
 }
@@ -200,10 +771,12 @@ func (self *controller) cameraGetZoom() (current, target float64) {
 
 func (self *controller) cameraSetShaker(newShaker shaker.Shaker, channels ...shaker.Channel) {
 	if self.inDraw {
-		panic("can't SetShaker during draw stage")
+		self.reportStageMisuse("can't SetShaker during draw stage")
+		return
 	}
 	if len(channels) > 1 {
-		panic("can't pass multiple shaker channels to SetShaker")
+		self.reportMisuse("can't pass multiple shaker channels to SetShaker")
+		return
 	} else if len(channels) == 0 {
 		self.shakerChannels[0].shaker = newShaker
 	} else {
@@ -217,7 +790,7 @@ func (self *controller) cameraSetShaker(newShaker shaker.Shaker, channels ...sha
 		// compact nils at the end of the slice
 		compactCount := 0
 		for i := len(self.shakerChannels) - 1; i > 0; i-- {
-			if self.shakerChannels[i].shaker != nil {
+			if self.shakerChannels[i].shaker != nil || self.shakerChannelFallback(i) != nil {
 				break
 			}
 			compactCount += 1
@@ -232,7 +805,8 @@ func (self *controller) cameraGetShaker(channels ...shaker.Channel) shaker.Shake
 	if len(channels) == 0 {
 		return self.shakerChannels[0].shaker
 	} else if len(channels) > 1 {
-		panic("can't GetShaker for multiple shaker channels at once")
+		self.reportMisuse("can't GetShaker for multiple shaker channels at once")
+		return nil
 	} else if int(channels[0]) >= len(self.shakerChannels) {
 		return nil
 	} else {
@@ -242,14 +816,16 @@ func (self *controller) cameraGetShaker(channels ...shaker.Channel) shaker.Shake
 
 func (self *controller) cameraStartShake(fadeIn TicksDuration, channels ...shaker.Channel) {
 	if self.inDraw {
-		panic("can't StartShake during draw stage")
+		self.reportStageMisuse("can't StartShake during draw stage")
+		return
 	}
 	if len(channels) == 0 {
 		self.shakerChannels[0].Start(fadeIn)
 	} else {
 		for _, channel := range channels {
 			if !self.shakerChannelAccessible(channel) {
-				panic("can't StartShake on uninitialized channels")
+				self.reportMisuse("can't StartShake on uninitialized channels")
+				continue
 			}
 			self.shakerChannels[channel].Start(fadeIn)
 		}
@@ -258,39 +834,123 @@ func (self *controller) cameraStartShake(fadeIn TicksDuration, channels ...shake
 
 func (self *controller) cameraEndShake(fadeOut TicksDuration, channels ...shaker.Channel) {
 	if self.inDraw {
-		panic("can't EndShake during draw stage")
+		self.reportStageMisuse("can't EndShake during draw stage")
+		return
 	}
 	if len(channels) == 0 {
 		self.shakerChannels[0].End(fadeOut)
 	} else {
 		for _, channel := range channels {
 			if !self.shakerChannelAccessible(channel) {
-				panic("can't EndShake on uninitialized channels")
+				self.reportMisuse("can't EndShake on uninitialized channels")
+				continue
 			}
 			self.shakerChannels[channel].End(fadeOut)
 		}
 	}
 }
 
+func (self *controller) cameraEnsureShaking(fadeIn TicksDuration, channels ...shaker.Channel) {
+	if self.inDraw {
+		self.reportStageMisuse("can't EnsureShaking during draw stage")
+		return
+	}
+	if len(channels) == 0 {
+		self.shakerChannels[0].EnsureShaking(fadeIn)
+	} else {
+		for _, channel := range channels {
+			if !self.shakerChannelAccessible(channel) {
+				self.reportMisuse("can't EnsureShaking on uninitialized channels")
+				continue
+			}
+			self.shakerChannels[channel].EnsureShaking(fadeIn)
+		}
+	}
+}
+
+func (self *controller) cameraEnsureNotShaking(fadeOut TicksDuration, channels ...shaker.Channel) {
+	if self.inDraw {
+		self.reportStageMisuse("can't EnsureNotShaking during draw stage")
+		return
+	}
+	if len(channels) == 0 {
+		self.shakerChannels[0].EnsureNotShaking(fadeOut)
+	} else {
+		for _, channel := range channels {
+			if !self.shakerChannelAccessible(channel) {
+				self.reportMisuse("can't EnsureNotShaking on uninitialized channels")
+				continue
+			}
+			self.shakerChannels[channel].EnsureNotShaking(fadeOut)
+		}
+	}
+}
+
 func (self *controller) cameraTriggerShake(fadeIn, duration, fadeOut TicksDuration, channels ...shaker.Channel) {
 	if self.inDraw {
-		panic("can't TriggerShake during draw stage")
+		self.reportStageMisuse("can't TriggerShake during draw stage")
+		return
+	}
+	if len(channels) == 0 {
+		self.shakerChannels[0].Trigger(fadeIn, duration, fadeOut, 1.0)
+	} else {
+		for _, channel := range channels {
+			if !self.shakerChannelAccessible(channel) {
+				self.reportMisuse("can't TriggerShake on uninitialized channels")
+				continue
+			}
+			self.shakerChannels[channel].Trigger(fadeIn, duration, fadeOut, 1.0)
+		}
+	}
+}
+
+func (self *controller) cameraTriggerShakeAt(worldX, worldY float64, maxRange float64, fadeIn, duration, fadeOut TicksDuration, channels ...shaker.Channel) {
+	if self.inDraw {
+		self.reportStageMisuse("can't TriggerShakeAt during draw stage")
+		return
+	}
+	if maxRange <= 0.0 {
+		self.reportMisuse("TriggerShakeAt maxRange must be strictly positive")
+		return
 	}
+	distance := math.Hypot(worldX-self.trackerCurrentX, worldY-self.trackerCurrentY)
+	if distance >= maxRange {
+		return
+	}
+	scale := 1.0 - distance/maxRange
 	if len(channels) == 0 {
-		self.shakerChannels[0].Trigger(fadeIn, duration, fadeOut)
+		self.shakerChannels[0].Trigger(fadeIn, duration, fadeOut, scale)
 	} else {
 		for _, channel := range channels {
 			if !self.shakerChannelAccessible(channel) {
-				panic("can't TriggerShake on uninitialized channels")
+				self.reportMisuse("can't TriggerShakeAt on uninitialized channels")
+				continue
 			}
-			self.shakerChannels[channel].Trigger(fadeIn, duration, fadeOut)
+			self.shakerChannels[channel].Trigger(fadeIn, duration, fadeOut, scale)
 		}
 	}
 }
 
+func (self *controller) cameraGetShakeRotation() float64 {
+	return self.shakerRotation
+}
+
+func (self *controller) cameraSetShakeFrozen(frozen bool) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetShakeFrozen during draw stage")
+		return
+	}
+	self.shakeFrozen = frozen
+}
+
+func (self *controller) cameraIsShakeFrozen() bool {
+	return self.shakeFrozen
+}
+
 func (self *controller) cameraIsShaking(channels ...shaker.Channel) bool {
 	if len(channels) > 1 {
-		panic("IsShaking accepts at most one shaker channel as argument")
+		self.reportMisuse("IsShaking accepts at most one shaker channel as argument")
+		return false
 	}
 
 	if len(channels) == 0 {
@@ -299,7 +959,7 @@ func (self *controller) cameraIsShaking(channels ...shaker.Channel) bool {
 				return true
 			}
 		}
-		return false
+		return self.traumaLevel > 0.0
 	} else if !self.shakerChannelAccessible(channels[0]) {
 		return false
 	} else {
@@ -307,7 +967,236 @@ func (self *controller) cameraIsShaking(channels ...shaker.Channel) bool {
 	}
 }
 
+// Channels are accessible if they have a shaker or a fallback.
+// Channels with a fallback that haven't been used yet are created
+// on demand.
 func (self *controller) shakerChannelAccessible(channel shaker.Channel) bool {
-	return (channel == 0 || (int(channel) < len(self.shakerChannels) &&
-		self.shakerChannels[channel].shaker != nil))
+	if int(channel) < len(self.shakerChannels) && self.shakerChannels[channel].shaker != nil {
+		return true
+	}
+	if self.shakerChannelFallback(int(channel)) == nil {
+		return false
+	}
+	if int(channel) >= len(self.shakerChannels) {
+		self.shakerChannels = setAt(self.shakerChannels, shakerChannel{}, int(channel))
+	}
+	return true
+}
+
+func (self *controller) cameraGetShakeChannelState(channel shaker.Channel) ShakeState {
+	if int(channel) >= len(self.shakerChannels) {
+		return ShakeState{}
+	}
+	ch := &self.shakerChannels[channel]
+	return ShakeState{FadeIn: ch.fadeIn, Duration: ch.duration, FadeOut: ch.fadeOut, Elapsed: ch.elapsed}
+}
+
+func (self *controller) cameraSetShakeChannelState(channel shaker.Channel, state ShakeState) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetShakeChannelState during draw stage")
+		return
+	}
+	if !self.shakerChannelAccessible(channel) {
+		self.reportMisuse("can't SetShakeChannelState on uninitialized channels")
+		return
+	}
+	ch := &self.shakerChannels[channel]
+	ch.fadeIn, ch.duration, ch.fadeOut, ch.elapsed = state.FadeIn, state.Duration, state.FadeOut, state.Elapsed
+	ch.boost = shakeBoost{}
+	ch.attenuation = 0.0
+}
+
+func (self *controller) cameraSetShakeFadeCurves(channel shaker.Channel, fadeInCurve, fadeOutCurve func(t float64) float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetShakeFadeCurves during draw stage")
+		return
+	}
+	if !self.shakerChannelAccessible(channel) {
+		self.reportMisuse("can't SetShakeFadeCurves on uninitialized channels")
+		return
+	}
+	self.shakerChannels[channel].fadeInCurve = fadeInCurve
+	self.shakerChannels[channel].fadeOutCurve = fadeOutCurve
+}
+
+func (self *controller) cameraSetActivityExponent(channel shaker.Channel, exponent float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetActivityExponent during draw stage")
+		return
+	}
+	if exponent <= 0.0 || math.IsNaN(exponent) || math.IsInf(exponent, 0) {
+		self.reportMisuse("activity exponent must be finite and strictly positive")
+		return
+	}
+	if !self.shakerChannelAccessible(channel) {
+		self.reportMisuse("can't SetActivityExponent on uninitialized channels")
+		return
+	}
+	self.shakerChannels[channel].activityExponent = exponent
+}
+
+func (self *controller) cameraSetChannelAxisMask(channel shaker.Channel, allowX, allowY bool) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetChannelAxisMask during draw stage")
+		return
+	}
+	if !self.shakerChannelAccessible(channel) {
+		self.reportMisuse("can't SetChannelAxisMask on uninitialized channels")
+		return
+	}
+	self.shakerChannels[channel].maskX = !allowX
+	self.shakerChannels[channel].maskY = !allowY
+}
+
+func (self *controller) cameraSetChannelDefault(channel shaker.Channel, fallback shaker.Shaker) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetChannelDefault during draw stage")
+		return
+	}
+	if fallback == nil && int(channel) >= len(self.shakerDefaults) {
+		return
+	}
+	self.shakerDefaults = setAt(self.shakerDefaults, fallback, int(channel))
+}
+
+func (self *controller) cameraOnShakeEnd(channel shaker.Channel, handler func()) {
+	if self.inDraw {
+		self.reportStageMisuse("can't OnShakeEnd during draw stage")
+		return
+	}
+	if handler == nil && int(channel) >= len(self.shakeEndHandlers) {
+		return
+	}
+	self.shakeEndHandlers = setAt(self.shakeEndHandlers, handler, int(channel))
+}
+
+// Returns the shaker to be used on the given channel when no
+// explicit shaker has been set. Channel zero falls back to a
+// [shaker.Random] if no default has been registered.
+func (self *controller) shakerChannelFallback(channel int) shaker.Shaker {
+	if channel < len(self.shakerDefaults) && self.shakerDefaults[channel] != nil {
+		return self.shakerDefaults[channel]
+	}
+	if channel == 0 {
+		if defaultShaker == nil {
+			defaultShaker = &shaker.Random{}
+		}
+		return defaultShaker
+	}
+	return nil
+}
+
+// ---- independent views ----
+
+func (self *controller) cameraRenderViewTo(target *ebiten.Image, centerX, centerY, zoom float64, drawFunc func(canvas *ebiten.Image)) {
+	if !self.inDraw {
+		self.reportStageMisuse("can't RenderViewTo outside draw stage")
+		return
+	}
+	if self.renderViewActive {
+		self.reportMisuse("can't nest RenderViewTo calls")
+		return
+	}
+	if zoom <= 0.0 {
+		self.reportMisuse("RenderViewTo zoom must be strictly positive")
+		return
+	}
+
+	// compute view area
+	bounds := target.Bounds()
+	viewWidth, viewHeight := float64(bounds.Dx())/zoom, float64(bounds.Dy())/zoom
+	minX, minY := centerX-viewWidth/2.0, centerY-viewHeight/2.0
+	self.renderAreaTo(target, minX, minY, minX+viewWidth, minY+viewHeight, drawFunc)
+}
+
+func (self *controller) projectToTarget(target *ebiten.Image, fit FitMode, drawFunc func(canvas *ebiten.Image)) {
+	if !self.inDraw {
+		self.reportStageMisuse("can't ProjectToTarget outside draw stage")
+		return
+	}
+	if self.renderViewActive {
+		self.reportMisuse("can't nest ProjectToTarget or RenderViewTo calls")
+		return
+	}
+	if fit >= fitModeEndSentinel {
+		self.reportMisuse("invalid FitMode")
+		return
+	}
+	bounds := target.Bounds()
+	if bounds.Empty() {
+		return
+	}
+
+	minX, minY, maxX, maxY := self.cameraAreaF64()
+	areaWidth, areaHeight := maxX-minX, maxY-minY
+	areaAspectRatio := areaWidth / areaHeight
+	targetAspectRatio := float64(bounds.Dx()) / float64(bounds.Dy())
+	switch fit {
+	case FitContain: // letterbox within the target
+		width, height := float64(bounds.Dx()), float64(bounds.Dy())
+		if targetAspectRatio > areaAspectRatio {
+			width = height * areaAspectRatio
+		} else {
+			height = width / areaAspectRatio
+		}
+		offsetX := (float64(bounds.Dx()) - width) / 2.0
+		offsetY := (float64(bounds.Dy()) - height) / 2.0
+		rect := image.Rect(
+			bounds.Min.X+int(math.Round(offsetX)), bounds.Min.Y+int(math.Round(offsetY)),
+			bounds.Min.X+int(math.Round(offsetX+width)), bounds.Min.Y+int(math.Round(offsetY+height)),
+		)
+		if rect.Empty() {
+			return
+		}
+		target = target.SubImage(rect).(*ebiten.Image)
+	case FitCover: // crop the camera area
+		centerX, centerY := (minX+maxX)/2.0, (minY+maxY)/2.0
+		if targetAspectRatio > areaAspectRatio {
+			areaHeight = areaWidth / targetAspectRatio
+		} else {
+			areaWidth = areaHeight * targetAspectRatio
+		}
+		minX, minY = centerX-areaWidth/2.0, centerY-areaHeight/2.0
+		maxX, maxY = minX+areaWidth, minY+areaHeight
+	case FitStretch:
+		// nothing to adjust
+	default:
+		panic("unreachable")
+	}
+	self.renderAreaTo(target, minX, minY, maxX, maxY, drawFunc)
+}
+
+// Renders the given logical area with drawFunc into a temporary
+// canvas and projects it to the whole target. While drawFunc runs,
+// the camera area is replaced by the given one.
+func (self *controller) renderAreaTo(target *ebiten.Image, minX, minY, maxX, maxY float64, drawFunc func(canvas *ebiten.Image)) {
+	area := image.Rect(
+		int(math.Floor(minX)), int(math.Floor(minY)),
+		int(math.Ceil(maxX)), int(math.Ceil(maxY)),
+	)
+
+	// get canvas
+	width, height := area.Dx(), area.Dy()
+	if self.renderViewCanvas == nil || self.renderViewCanvas.Bounds().Dx() < width || self.renderViewCanvas.Bounds().Dy() < height {
+		if self.renderViewCanvas != nil {
+			self.renderViewCanvas.Deallocate()
+		}
+		self.renderViewCanvas = ebiten.NewImage(width, height)
+	}
+	canvas := self.renderViewCanvas.SubImage(image.Rect(0, 0, width, height)).(*ebiten.Image)
+	canvas.Clear()
+
+	// temporarily replace camera area, draw and project
+	mainArea := self.cameraArea
+	self.renderViewActive = true
+	self.renderViewArea = [4]float64{minX, minY, maxX, maxY}
+	self.cameraArea = area
+	internal.BridgedCameraOrigin = area.Min
+	defer func() {
+		self.renderViewActive = false
+		self.cameraArea = mainArea
+		internal.BridgedCameraOrigin = mainArea.Min
+	}()
+	drawFunc(canvas)
+	self.projectLogicalArea(canvas, target, area.Min, minX, minY, maxX, maxY, false)
 }
@@ -0,0 +1,110 @@
+package mipix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTightCanvasFractionalPositions(t *testing.T) {
+	const Width, Height = 320, 180
+
+	ctrl := newTestController(Width, Height)
+	ctrl.scalingSetTightCanvas(true)
+	for _, zoom := range []float64{1.0, 0.75, 1.5, 2.0, 3.3} {
+		ctrl.cameraZoomReset(zoom)
+		expectedWidth := int(math.Ceil(Width / zoom))
+		expectedHeight := int(math.Ceil(Height / zoom))
+		for _, pos := range []float64{0.0, 0.25, 0.5, 0.75, 10.3, -7.6} {
+			ctrl.trackerCurrentX, ctrl.trackerCurrentY = pos, -pos/3.0
+			ctrl.updateCameraArea()
+
+			area := ctrl.cameraAreaGet()
+			if area.Dx() != expectedWidth || area.Dy() != expectedHeight {
+				t.Fatalf("zoom %.2f, pos %.2f: expected area of %dx%d, got %dx%d",
+					zoom, pos, expectedWidth, expectedHeight, area.Dx(), area.Dy())
+			}
+			bounds := ctrl.getLogicalCanvas().Bounds()
+			if bounds.Dx() != area.Dx() || bounds.Dy() != area.Dy() {
+				t.Fatalf("zoom %.2f, pos %.2f: expected logical canvas of %dx%d, got %dx%d",
+					zoom, pos, area.Dx(), area.Dy(), bounds.Dx(), bounds.Dy())
+			}
+		}
+	}
+}
+
+func TestLooseCanvasFractionalPositions(t *testing.T) {
+	ctrl := newTestController(320, 180)
+	ctrl.trackerCurrentX, ctrl.trackerCurrentY = 0.25, 0.25
+	ctrl.updateCameraArea()
+
+	// without tight canvas, fractional positions need an extra pixel
+	area := ctrl.cameraAreaGet()
+	if area.Dx() != 321 || area.Dy() != 181 {
+		t.Fatalf("expected area of 321x181, got %dx%d", area.Dx(), area.Dy())
+	}
+}
+
+func TestFocusOnRespectsZoomLimits(t *testing.T) {
+	ctrl := newTestController(320, 180)
+	ctrl.cameraSetZoomLimits(0.5, 2.0)
+	ctrl.cameraFocusOn(10, 10, 4.0, 30, nil)
+	if ctrl.zoomTarget != 2.0 || ctrl.focus.toZoom != 2.0 {
+		t.Fatalf("expected zoom target 2.0, got %.2f (focus %.2f)", ctrl.zoomTarget, ctrl.focus.toZoom)
+	}
+}
+
+// Zoomer that reaches the target immediately.
+type instantZoomer struct{}
+
+func (instantZoomer) Reset() {}
+func (instantZoomer) Update(currentZoom, targetZoom float64) float64 {
+	return targetZoom - currentZoom
+}
+
+func TestZoomAnchorRelease(t *testing.T) {
+	ctrl := newTestController(320, 180)
+
+	// released within tolerance of the target
+	ctrl.cameraZoomTowards(2.0, 50, 50)
+	ctrl.zoomCurrent = 2.0 * (1.0 + 1e-6)
+	ctrl.updateZoomAnchor()
+	if ctrl.zoomAnchor.active {
+		t.Fatal("expected anchor to be released near the zoom target")
+	}
+
+	// released by notifying coordinates
+	ctrl.cameraZoomReset(1.0)
+	ctrl.cameraZoomTowards(2.0, 50, 50)
+	ctrl.cameraNotifyCoordinates(-30, 40)
+	if ctrl.zoomAnchor.active {
+		t.Fatal("expected anchor to be released by NotifyCoordinates")
+	}
+	ctrl.updateZoomAnchor()
+	if ctrl.trackerTargetX != -30 || ctrl.trackerTargetY != 40 {
+		t.Fatalf("expected notified coordinates to remain, got (%.2f, %.2f)", ctrl.trackerTargetX, ctrl.trackerTargetY)
+	}
+
+	// released by notifying a delta
+	ctrl.cameraZoomReset(1.0)
+	ctrl.cameraNotifyCoordinates(0, 0)
+	ctrl.cameraZoomTowards(2.0, 50, 50)
+	ctrl.cameraNotifyDelta(5, -5)
+	if ctrl.zoomAnchor.active {
+		t.Fatal("expected anchor to be released by NotifyDelta")
+	}
+	ctrl.updateZoomAnchor()
+	if ctrl.trackerTargetX != 5 || ctrl.trackerTargetY != -5 {
+		t.Fatalf("expected notified delta to remain, got (%.2f, %.2f)", ctrl.trackerTargetX, ctrl.trackerTargetY)
+	}
+
+	// released when the current zoom gets clamped
+	ctrl.cameraZoomReset(1.0)
+	ctrl.cameraSetZoomer(instantZoomer{})
+	ctrl.cameraSetZoomSafetyPolicy(ZoomSafetyClamp)
+	ctrl.cameraZoomTowards(1000.0, 50, 50)
+	ctrl.updateZoom()
+	ctrl.updateZoomAnchor()
+	if ctrl.zoomCurrent != 500.0 || ctrl.zoomAnchor.active {
+		t.Fatalf("expected clamped zoom and released anchor, got zoom %.2f", ctrl.zoomCurrent)
+	}
+}
@@ -0,0 +1,62 @@
+package mipix
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type logicalCaptureRequest struct {
+	scale    int
+	callback func(*image.RGBA)
+}
+
+func (self *controller) captureLogical(scale int, callback func(*image.RGBA)) {
+	if scale < 1 {
+		self.reportMisuse("CaptureLogical scale must be at least 1")
+		return
+	}
+	if callback == nil {
+		self.reportMisuse("CaptureLogical callback can't be nil")
+		return
+	}
+	self.logicalCaptures = append(self.logicalCaptures, logicalCaptureRequest{scale, callback})
+}
+
+// Called right after the logical canvas has been drawn by the game.
+func (self *controller) resolveLogicalCaptures(logicalCanvas *ebiten.Image) {
+	if len(self.logicalCaptures) == 0 {
+		return
+	}
+
+	bounds := logicalCanvas.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pixels := make([]byte, 4*width*height)
+	logicalCanvas.ReadPixels(pixels)
+
+	for i, request := range self.logicalCaptures {
+		request.callback(upscaleNearest(pixels, width, height, request.scale))
+		self.logicalCaptures[i] = logicalCaptureRequest{}
+	}
+	self.logicalCaptures = self.logicalCaptures[:0]
+}
+
+// Pixels are expected in premultiplied RGBA order, which is what
+// both ebiten.Image.ReadPixels() and image.RGBA use.
+func upscaleNearest(pixels []byte, width, height int, scale int) *image.RGBA {
+	out := image.NewRGBA(image.Rect(0, 0, width*scale, height*scale))
+	for y := 0; y < height; y++ {
+		srcRow := pixels[y*width*4 : (y+1)*width*4]
+		dstRow := out.Pix[y*scale*out.Stride : y*scale*out.Stride+width*scale*4]
+		for x := 0; x < width; x++ {
+			pixel := srcRow[x*4 : x*4+4]
+			for i := 0; i < scale; i++ {
+				copy(dstRow[(x*scale+i)*4:], pixel)
+			}
+		}
+		for i := 1; i < scale; i++ {
+			copy(out.Pix[(y*scale+i)*out.Stride:], dstRow)
+		}
+	}
+	return out
+}
@@ -1,18 +1,87 @@
 package mipix
 
-import ebimath "github.com/edwinsyarief/ebi-math"
+import (
+	"math"
+
+	ebimath "github.com/edwinsyarief/ebi-math"
+	"github.com/hajimehoshi/ebiten/v2"
+)
 
 func (self *controller) convertToRelativeCoords(x, y int) (float64, float64) {
 	xMargin, yMargin := self.hackyGetMargins()
-	relX := (float64(x) - xMargin) / (float64(self.hiResWidth) - xMargin*2)
-	relY := (float64(y) - yMargin) / (float64(self.hiResHeight) - yMargin*2)
-	return ebimath.Clamp(relX, 0.0, 1.0), ebimath.Clamp(relY, 0.0, 1.0)
+	activeWidth := float64(self.hiResWidth) - xMargin*2
+	activeHeight := float64(self.hiResHeight) - yMargin*2
+	if activeWidth <= 0 || activeHeight <= 0 { // degenerate, e.g. minimized window
+		return 0.5, 0.5
+	}
+
+	// undo the viewport scale and shake rotation applied
+	// around the center of the active area on projection
+	fx, fy := float64(x), float64(y)
+	if self.viewportScale != 1.0 || self.shakerRotation != 0.0 {
+		centerX, centerY := xMargin+activeWidth/2.0, yMargin+activeHeight/2.0
+		fx, fy = untransformPoint(fx, fy, centerX, centerY, self.viewportScale, self.shakerRotation)
+	}
+	relX := (fx - xMargin) / activeWidth
+	relY := (fy - yMargin) / activeHeight
+	relX, relY = ebimath.Clamp(relX, 0.0, 1.0), ebimath.Clamp(relY, 0.0, 1.0)
+	switch self.orientation {
+	case Orientation90:
+		return relY, 1.0 - relX
+	case Orientation180:
+		return 1.0 - relX, 1.0 - relY
+	case Orientation270:
+		return 1.0 - relY, relX
+	default:
+		return relX, relY
+	}
 }
 
 func (self *controller) convertToLogicalCoords(x, y int) (float64, float64) {
 	rx, ry := self.convertToRelativeCoords(x, y)
 	minX, minY, _, _ := self.cameraAreaF64()
-	return minX + rx*float64(self.logicalWidth)/self.zoomCurrent, minY + ry*float64(self.logicalHeight)/self.zoomCurrent
+	zoom := self.effectiveZoom()
+	return minX + rx*float64(self.logicalWidth)/zoom, minY + ry*float64(self.logicalHeight)/zoom
+}
+
+// inverse of convertToLogicalCoords, without clamping
+func (self *controller) convertFromLogicalCoordsF64(x, y float64) (float64, float64) {
+	xMargin, yMargin := self.hackyGetMargins()
+	activeWidth := float64(self.hiResWidth) - xMargin*2
+	activeHeight := float64(self.hiResHeight) - yMargin*2
+	if activeWidth <= 0 || activeHeight <= 0 { // degenerate, e.g. minimized window
+		return 0, 0
+	}
+
+	minX, minY, _, _ := self.cameraAreaF64()
+	zoom := self.effectiveZoom()
+	rx := (x - minX) * zoom / float64(self.logicalWidth)
+	ry := (y - minY) * zoom / float64(self.logicalHeight)
+	var relX, relY float64
+	switch self.orientation {
+	case Orientation90:
+		relX, relY = 1.0-ry, rx
+	case Orientation180:
+		relX, relY = 1.0-rx, 1.0-ry
+	case Orientation270:
+		relX, relY = ry, 1.0-rx
+	default:
+		relX, relY = rx, ry
+	}
+	screenX, screenY := xMargin+relX*activeWidth, yMargin+relY*activeHeight
+	if self.viewportScale != 1.0 || self.shakerRotation != 0.0 {
+		centerX, centerY := xMargin+activeWidth/2.0, yMargin+activeHeight/2.0
+		screenX, screenY = transformPoint(screenX, screenY, centerX, centerY, self.viewportScale, self.shakerRotation)
+	}
+	return screenX, screenY
+}
+
+func (self *controller) convertFromLogicalCoords(x, y float64) (int, int) {
+	// tiny epsilon to compensate floating point errors on
+	// coordinates coming from convertToLogicalCoords
+	const epsilon = 1e-6
+	screenX, screenY := self.convertFromLogicalCoordsF64(x, y)
+	return int(math.Floor(screenX + epsilon)), int(math.Floor(screenY + epsilon))
 }
 
 func (self *controller) convertToGameResolution(x, y int) (float64, float64) {
@@ -32,9 +101,12 @@ func (self *controller) hackyGetMargins() (float64, float64) {
 		hiWidth = self.hiResWidth
 		hiHeight = self.hiResHeight
 	}
+	if hiWidth < 1 || hiHeight < 1 {
+		return 0, 0
+	}
 
 	hiAspectRatio := float64(hiWidth) / float64(hiHeight)
-	loAspectRatio := float64(self.logicalWidth) / float64(self.logicalHeight)
+	loAspectRatio := self.displayAspectRatio()
 	switch {
 	case hiAspectRatio == loAspectRatio: // just scaling
 		return 0, 0
@@ -48,3 +120,38 @@ func (self *controller) hackyGetMargins() (float64, float64) {
 		panic("unreachable")
 	}
 }
+
+func (self *controller) convertWorldToScreenGeoM() ebiten.GeoM {
+	active := self.activeHiResBounds
+	activeWidth, activeHeight := float64(active.Dx()), float64(active.Dy())
+	minX, minY, maxX, maxY := self.cameraAreaF64()
+
+	var geom ebiten.GeoM
+	geom.Translate(-minX, -minY)
+	if self.orientationSwapsAxes() {
+		geom.Scale(activeHeight/(maxX-minX), activeWidth/(maxY-minY))
+	} else {
+		geom.Scale(activeWidth/(maxX-minX), activeHeight/(maxY-minY))
+	}
+	switch self.orientation {
+	case Orientation90:
+		geom.Rotate(math.Pi / 2.0)
+		geom.Translate(activeWidth, 0)
+	case Orientation180:
+		geom.Rotate(math.Pi)
+		geom.Translate(activeWidth, activeHeight)
+	case Orientation270:
+		geom.Rotate(-math.Pi / 2.0)
+		geom.Translate(0, activeHeight)
+	}
+	geom.Translate(float64(active.Min.X), float64(active.Min.Y))
+	if self.viewportScale != 1.0 || self.shakerRotation != 0.0 {
+		centerX := float64(active.Min.X+active.Max.X) / 2.0
+		centerY := float64(active.Min.Y+active.Max.Y) / 2.0
+		geom.Translate(-centerX, -centerY)
+		geom.Scale(self.viewportScale, self.viewportScale)
+		geom.Rotate(self.shakerRotation)
+		geom.Translate(centerX, centerY)
+	}
+	return geom
+}
@@ -0,0 +1,40 @@
+package mipix
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestConvertViewportTransform(t *testing.T) {
+	ctrl := newTestController(320, 180)
+	ctrl.hiResWidth, ctrl.hiResHeight = 640, 360
+	ctrl.activeHiResBounds = image.Rect(0, 0, 640, 360)
+	ctrl.viewportScale = 0.6 // keeps the rotated corners on screen
+	ctrl.shakerRotation = 0.3
+
+	geom := ctrl.convertWorldToScreenGeoM()
+	minX, minY, maxX, maxY := ctrl.cameraAreaF64()
+	points := [][2]float64{
+		{minX, minY}, {maxX, maxY}, {(minX + maxX) / 2.0, (minY + maxY) / 2.0},
+		{minX + 17.25, maxY - 3.5},
+	}
+	for _, point := range points {
+		screenX, screenY := ctrl.convertFromLogicalCoordsF64(point[0], point[1])
+		geomX, geomY := geom.Apply(point[0], point[1])
+		if math.Abs(screenX-geomX) > 1e-6 || math.Abs(screenY-geomY) > 1e-6 {
+			t.Fatalf("point %v: conversion (%f, %f) doesn't match geom (%f, %f)", point, screenX, screenY, geomX, geomY)
+		}
+
+		logicalX, logicalY := ctrl.convertToLogicalCoords(int(math.Round(screenX)), int(math.Round(screenY)))
+		if math.Abs(logicalX-point[0]) > 1.0 || math.Abs(logicalY-point[1]) > 1.0 {
+			t.Fatalf("point %v: round trip returned (%f, %f)", point, logicalX, logicalY)
+		}
+	}
+
+	// the center of the camera stays at the center of the screen
+	centerX, centerY := ctrl.convertFromLogicalCoordsF64((minX+maxX)/2.0, (minY+maxY)/2.0)
+	if math.Abs(centerX-320) > 1e-6 || math.Abs(centerY-180) > 1e-6 {
+		t.Fatalf("expected camera center at (320, 180), got (%f, %f)", centerX, centerY)
+	}
+}
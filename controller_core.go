@@ -1,13 +1,17 @@
 package mipix
 
 import (
+	"cmp"
 	"image"
 	"image/color"
 	"math"
+	"slices"
 
 	ebimath "github.com/edwinsyarief/ebi-math"
 	"github.com/edwinsyarief/mipix/internal"
+	"github.com/edwinsyarief/mipix/shaker"
 	"github.com/edwinsyarief/mipix/tracker"
+	"github.com/edwinsyarief/mipix/tween"
 	"github.com/edwinsyarief/mipix/utils"
 	"github.com/edwinsyarief/mipix/zoomer"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -16,19 +20,36 @@ import (
 var pkgController controller
 
 func init() {
-	pkgController.cameraZoomReset(1.0)
-	pkgController.tickSetRate(1)
-	pkgController.shakerChannels = make([]shakerChannel, 1)
-	pkgController.lastFlushCoordinatesTick = 0xFFFF_FFFF_FFFF_FFFF
-	pkgController.bestFitRenderSize = ebimath.V(180, 180)
-	pkgController.bestFitContextSize = ebimath.V(1000, 1000)
-	pkgController.needsRedraw = true
+	pkgController.setDefaults()
+}
+
+func (self *controller) setDefaults() {
+	self.cameraZoomReset(1.0)
+	self.tickSetRate(1)
+	self.shakerChannels = make([]shakerChannel, 1)
+	self.lastFlushCoordinatesTick = 0xFFFF_FFFF_FFFF_FFFF
+	self.bestFitRenderSize = ebimath.V(180, 180)
+	self.bestFitContextSize = ebimath.V(1000, 1000)
+	self.needsRedraw = true
+	self.viewportScale = 1.0
+	self.aaWidth = 1.0
+	self.zoomLimitMax = math.Inf(1)
+}
+
+// See controller.cameraZoomTowards().
+type zoomAnchor struct {
+	active         bool
+	worldX, worldY float64
+	relX, relY     float64 // relative position within the camera area
 }
 
 type controller struct {
 	// core state
 	game                  Game
+	manualStepping        bool
+	panicPolicy           PanicPolicy
 	queuedDraws           []queuedDraw
+	logicalCaptures       []logicalCaptureRequest
 	reusableCanvas        *ebiten.Image // this preserves the highest size requested by resolution or zooms
 	logicalWidth          int
 	logicalHeight         int
@@ -37,21 +58,42 @@ type controller struct {
 	prevHiResCanvasWidth  int // used to update layoutHasChanged even on unexpected cases *
 	prevHiResCanvasHeight int // used to update layoutHasChanged even on unexpected cases
 	// * https://github.com/hajimehoshi/ebiten/issues/2978
-	layoutHasChanged   bool
-	inDraw             bool
-	redrawManaged      bool
-	needsRedraw        bool
-	needsClear         bool
-	stretchingEnabled  bool
-	keepAspectRatio    bool
-	dynamicScaling     bool
-	scalingFilter      ScalingFilter
-	bestFitRenderSize  ebimath.Vector
-	bestFitContextSize ebimath.Vector
+	layoutHasChanged    bool
+	layoutGeneration    uint64 // incremented on layout and resolution changes
+	deviceScale         float64
+	deviceScaleChanged  bool
+	inDraw              bool
+	redrawManaged       bool
+	needsRedraw         bool
+	dirtyRects          []image.Rectangle // logical areas requested for redraw, see redrawRequestArea()
+	lastFrameRedrawn    bool
+	needsClear          bool
+	skipDegenerateDraws bool
+	fastIntegerPath     bool
+	viewportScale       float64
+	stretchingEnabled   bool
+	keepAspectRatio     bool
+	tightCanvas         bool
+	hybridPixelMode     bool
+	fixedLogicalCanvas  bool
+	orientation         Orientation
+	horzBarColor        color.Color
+	vertBarColor        color.Color
+	dynamicScaling      bool
+	scalingFilter       ScalingFilter
+	aaWidth             float64 // multiplier for the relative texture unit uniforms, see scalingSetQuality()
+	bestFitRenderSize   ebimath.Vector
+	bestFitContextSize  ebimath.Vector
 
 	// camera
 	lastFlushCoordinatesTick uint64
 	cameraArea               image.Rectangle
+	areaChangedHandler       func(old, new image.Rectangle)
+	cameraPrevArea           image.Rectangle
+	activeHiResBounds        image.Rectangle
+	renderViewActive         bool
+	renderViewArea           [4]float64 // minX, minY, maxX, maxY
+	renderViewCanvas         *ebiten.Image
 
 	// tracking
 	tracker           tracker.Tracker
@@ -61,45 +103,151 @@ type controller struct {
 	trackerTargetY    float64
 	trackerPrevSpeedX float64
 	trackerPrevSpeedY float64
+	trackingDisabled  bool
+	constraint        func(x, y float64) (float64, float64)
+	frameVelocityX    float64
+	frameVelocityY    float64
+	hasBounds         bool
+	bounds            [4]float64 // minX, minY, maxX, maxY
+	followPoints      func() []ebimath.Vector
+	followSelector    func([]ebimath.Vector) ebimath.Vector
+
+	// scripted sweeps
+	sweep         sweepTracker
+	sweepOnArrive func()
+	focus         focusPull
+
+	// scroll window
+	scrollMode    ScrollMode
+	scrollLeft    float64
+	scrollRight   float64
+	scrollCenterX float64
 
 	// zoom
-	zoomer      zoomer.Zoomer
-	zoomCurrent float64
-	zoomTarget  float64
+	zoomer           zoomer.Zoomer
+	zoomCurrent      float64
+	zoomTarget       float64
+	zoomSafetyPolicy ZoomSafetyPolicy
+	zoomAnchor       zoomAnchor
+	zoomLimitMin     float64
+	zoomLimitMax     float64
+
+	// zoom pulses
+	zoomPulseMagnitude float64
+	zoomPulseElapsed   TicksDuration
+	zoomPulseDuration  TicksDuration
 
 	// shake
-	shakerChannels []shakerChannel
-	shakerOffsetX  float64
-	shakerOffsetY  float64
+	shakerChannels   []shakerChannel
+	shakerDefaults   []shaker.Shaker
+	shakeEndHandlers []func()
+	shakerOffsetX    float64
+	shakerOffsetY    float64
+	shakerRotation   float64
+	shakeFrozen      bool
+
+	// trauma
+	traumaLevel    float64
+	traumaDecayOff float64 // decay per second - 1.0
+	traumaShaker   shaker.Shaker
+	traumaActive   bool
+
+	// recoil
+	recoils []recoil
 
 	// ticks
 	currentTick uint64
 	tickRate    uint64
 
 	// shaders
-	shaderOpts        ebiten.DrawTrianglesShaderOptions
-	shaderVertices    []ebiten.Vertex
-	shaderVertIndices []uint16
-	shaders           [scalingFilterEndSentinel]*ebiten.Shader
+	shaderOpts         ebiten.DrawTrianglesShaderOptions
+	shaderVertices     []ebiten.Vertex
+	shaderVertIndices  []uint16
+	shaders            [scalingFilterEndSentinel]*ebiten.Shader
+	shaderErrorHandler func(ScalingFilter, error)
+	shaderFailed       [scalingFilterEndSentinel]bool
+
+	// filter crossfade
+	crossfadeFrom     ScalingFilter
+	crossfadeElapsed  TicksDuration
+	crossfadeDuration TicksDuration
+	crossfadeBuffer   *ebiten.Image
+
+	// transition filter
+	transitionFilter       ScalingFilter
+	hasTransitionFilter    bool
+	transitionFilterActive bool
+
+	// screen fades
+	fadeColor color.Color
+	fadeTween tween.Tween
+	fadeAlpha float64
+
+	// color overlays
+	overlays      []overlay
+	overlayNextID OverlayID
+
+	// reveal masks
+	revealShape    MaskShape
+	revealProgress float64
+	revealColor    color.Color
+
+	// built-in cursor
+	cursorImage    *ebiten.Image
+	cursorHotspotX int
+	cursorHotspotY int
+	cursorX        int
+	cursorY        int
+
+	// bloom
+	bloomThreshold float64
+	bloomIntensity float64
+	bloomShader    *ebiten.Shader
+	bloomFailed    bool
+	bloomBuffer    *ebiten.Image
+	bloomOpts      ebiten.DrawTrianglesShaderOptions
 
 	// debug
-	debugInfo      []string
-	debugOffscreen *Offscreen
+	debugInfo          []string
+	debugOffscreen     *Offscreen
+	debugRuler         bool
+	debugCorner        Corner
+	debugBeforeHiRes   bool
+	debugHeatmap       bool
+	debugLenient       bool
+	debugLastDrawQueue []queuedDraw
+	redrawHistory      uint64 // one bit per frame, most recent frame on the lowest bit
+	redrawCellHistory  [debugHeatmapCols * debugHeatmapRows]uint64
 }
 
 // --- ebiten.Game implementation ---
 
 func (self *controller) Update() error {
-	self.currentTick += self.tickRate
+	if !self.manualStepping {
+		self.currentTick += self.tickRate
+	}
 	err := self.game.Update()
 	if err != nil {
 		return err
 	}
-	self.cameraFlushCoordinates()
+	if !self.manualStepping {
+		self.updateTickEffects()
+	}
 	self.layoutHasChanged = false
+	self.deviceScaleChanged = false
 	return nil
 }
 
+// Updates the camera and all tick-based effects. Called once
+// per update, or once per step with manual stepping.
+func (self *controller) updateTickEffects() {
+	self.cameraFlushCoordinates()
+	self.updateFilterCrossfade()
+	self.updateTransitionFilter()
+	self.updateOverlays()
+	self.updateCursor()
+}
+
 func (self *controller) Draw(hiResCanvas *ebiten.Image) {
 	self.inDraw = true
 
@@ -113,24 +261,59 @@ func (self *controller) Draw(hiResCanvas *ebiten.Image) {
 		self.prevHiResCanvasWidth = hiResWidth
 		self.prevHiResCanvasHeight = hiResHeight
 		self.layoutHasChanged = true
+		self.layoutGeneration += 1
+		self.needsRedraw = true
+	}
+
+	// ebitengine never passes empty canvases to Draw(), so
+	// degenerate sizes are detected from the Layout() sizes
+	if self.skipDegenerateDraws && (self.hiResWidth < 1 || self.hiResHeight < 1) {
+		// queued draws and debug info are discarded, but the
+		// redraw remains pending for the next regular frame
+		clear(self.queuedDraws)
+		self.queuedDraws = self.queuedDraws[:0]
+		self.debugInfo = self.debugInfo[:0]
+		self.lastFrameRedrawn = false
+		self.redrawHistory <<= 1
+		self.inDraw = false
+		return
+	}
+
+	// area requests also trigger redraws, but only
+	// other requests count as full frame redraws
+	fullRedraw := !self.redrawManaged || self.needsRedraw
+	if len(self.dirtyRects) > 0 {
 		self.needsRedraw = true
 	}
 
 	logicalCanvas := self.getLogicalCanvas()
 	activeCanvas := self.getActiveHiResCanvas(hiResCanvas)
+	self.activeHiResBounds = activeCanvas.Bounds()
 	if self.needsClear {
 		self.needsClear = false
 		hiResCanvas.Clear()
 		logicalCanvas.Clear()
 	}
+	if !self.redrawManaged || self.needsRedraw {
+		self.fillBars(hiResCanvas, self.activeHiResBounds)
+		if self.viewportScale < 1.0 || self.shakerRotation != 0.0 {
+			activeCanvas.Clear() // the projection won't cover the whole area
+		}
+	}
 	self.game.Draw(logicalCanvas)
+	self.resolveLogicalCaptures(logicalCanvas)
 
 	var drawIndex int = 0
 	var prevDrawWasHiRes bool = false
+	var debugDrawn bool = false
 	for drawIndex < len(self.queuedDraws) {
 		if self.queuedDraws[drawIndex].IsHighResolution() {
 			if !prevDrawWasHiRes {
 				self.projectLogical(logicalCanvas, activeCanvas)
+				if self.debugBeforeHiRes && !debugDrawn && (!self.redrawManaged || self.needsRedraw) {
+					self.debugDrawAll(activeCanvas)
+					debugDrawn = true
+				}
 			}
 			self.queuedDraws[drawIndex].hiResFunc(hiResCanvas, activeCanvas)
 			prevDrawWasHiRes = true
@@ -143,16 +326,32 @@ func (self *controller) Draw(hiResCanvas *ebiten.Image) {
 		}
 		drawIndex += 1
 	}
+	self.debugLastDrawQueue = append(self.debugLastDrawQueue[:0], self.queuedDraws...)
+	clear(self.queuedDraws)
 	self.queuedDraws = self.queuedDraws[:0]
 
 	// final projection
+	self.debugRecordRedrawCells(fullRedraw)
 	if !self.redrawManaged || self.needsRedraw {
 		if !prevDrawWasHiRes {
 			self.projectLogical(logicalCanvas, activeCanvas)
 		}
-		self.debugDrawAll(activeCanvas)
+		self.applyBloom(activeCanvas)
+		self.drawRevealMask(activeCanvas)
+		self.drawOverlays(activeCanvas)
+		self.drawCursor(activeCanvas)
+		self.debugDrawRedrawHeatmap(activeCanvas)
+		if !debugDrawn {
+			self.debugDrawAll(activeCanvas)
+		}
+	}
+	self.lastFrameRedrawn = !self.redrawManaged || self.needsRedraw
+	self.redrawHistory <<= 1
+	if self.lastFrameRedrawn {
+		self.redrawHistory |= 1
 	}
 	self.needsRedraw = false
+	self.dirtyRects = self.dirtyRects[:0]
 	self.inDraw = false
 }
 
@@ -201,8 +400,11 @@ func (self *controller) getActiveHiResCanvas(hiResCanvas *ebiten.Image) *ebiten.
 	// crop margins based on aspect ratios
 	hiBounds := hiResCanvas.Bounds()
 	hiWidth, hiHeight := hiBounds.Dx(), hiBounds.Dy()
+	if hiWidth < 1 || hiHeight < 1 { // degenerate, e.g. minimized window
+		return hiResCanvas
+	}
 	hiAspectRatio := float64(hiWidth) / float64(hiHeight)
-	loAspectRatio := float64(self.logicalWidth) / float64(self.logicalHeight)
+	loAspectRatio := self.displayAspectRatio()
 
 	switch {
 	case hiAspectRatio == loAspectRatio: // just scaling
@@ -218,9 +420,41 @@ func (self *controller) getActiveHiResCanvas(hiResCanvas *ebiten.Image) *ebiten.
 	}
 }
 
+// Fills the letterboxing or pillarboxing bars around the active
+// area, if the relevant colors have been set.
+func (self *controller) fillBars(hiResCanvas *ebiten.Image, active image.Rectangle) {
+	full := hiResCanvas.Bounds()
+	if active.Dy() < full.Dy() && self.horzBarColor != nil { // top and bottom
+		utils.SubImage(hiResCanvas, full.Min.X, full.Min.Y, full.Max.X, active.Min.Y).Fill(self.horzBarColor)
+		utils.SubImage(hiResCanvas, full.Min.X, active.Max.Y, full.Max.X, full.Max.Y).Fill(self.horzBarColor)
+	}
+	if active.Dx() < full.Dx() && self.vertBarColor != nil { // left and right
+		utils.SubImage(hiResCanvas, full.Min.X, full.Min.Y, active.Min.X, full.Max.Y).Fill(self.vertBarColor)
+		utils.SubImage(hiResCanvas, active.Max.X, full.Min.Y, full.Max.X, full.Max.Y).Fill(self.vertBarColor)
+	}
+}
+
+func (self *controller) scalingSetPillarboxColors(horzBarColor, vertBarColor color.Color) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change pillarbox colors during draw stage")
+		return
+	}
+	self.horzBarColor, self.vertBarColor = horzBarColor, vertBarColor
+	self.needsRedraw = true
+	self.needsClear = true
+}
+
+func (self *controller) scalingGetBars() (horizontal, vertical bool) {
+	active := self.activeHiResBounds
+	horizontal = active.Dy() < self.prevHiResCanvasHeight
+	vertical = active.Dx() < self.prevHiResCanvasWidth
+	return horizontal, vertical
+}
+
 func (self *controller) Layout(logicWinWidth, logicWinHeight int) (int, int) {
 	monitor := ebiten.Monitor()
 	scale := monitor.DeviceScaleFactor()
+	self.registerDeviceScale(scale)
 	hiResWidth := int(float64(logicWinWidth) * scale)
 	hiResHeight := int(float64(logicWinHeight) * scale)
 
@@ -230,6 +464,7 @@ func (self *controller) Layout(logicWinWidth, logicWinHeight int) (int, int) {
 	}
 	if hiResWidth != self.hiResWidth || hiResHeight != self.hiResHeight {
 		self.layoutHasChanged = true
+		self.layoutGeneration += 1
 		self.needsRedraw = true
 		self.hiResWidth, self.hiResHeight = hiResWidth, hiResHeight
 	}
@@ -239,6 +474,7 @@ func (self *controller) Layout(logicWinWidth, logicWinHeight int) (int, int) {
 func (self *controller) LayoutF(logicWinWidth, logicWinHeight float64) (float64, float64) {
 	monitor := ebiten.Monitor()
 	scale := monitor.DeviceScaleFactor()
+	self.registerDeviceScale(scale)
 	outWidth := math.Ceil(logicWinWidth * scale)
 	outHeight := math.Ceil(logicWinHeight * scale)
 
@@ -248,6 +484,7 @@ func (self *controller) LayoutF(logicWinWidth, logicWinHeight float64) (float64,
 	}
 	if int(outWidth) != self.hiResWidth || int(outHeight) != self.hiResHeight {
 		self.layoutHasChanged = true
+		self.layoutGeneration += 1
 		self.needsRedraw = true
 		self.hiResWidth, self.hiResHeight = int(outWidth), int(outHeight)
 	}
@@ -274,14 +511,17 @@ func (self *controller) getResolution() (width, height int) {
 
 func (self *controller) setResolution(width, height int) {
 	if self.inDraw {
-		panic("can't change resolution during draw stage")
+		self.reportStageMisuse("can't change resolution during draw stage")
+		return
 	}
 	if width < 1 || height < 1 {
-		panic("game resolution must be at least (1, 1)")
+		self.reportMisuse("game resolution must be at least (1, 1)")
+		return
 	}
 	if width != self.logicalWidth || height != self.logicalHeight {
 		self.needsRedraw = true
 		self.logicalWidth, self.logicalHeight = width, height
+		self.layoutGeneration += 1
 		internal.BridgedLogicalWidth, internal.BridgedLogicalHeight = width, height // hyper massive hack
 		self.updateCameraArea()
 	}
@@ -289,10 +529,12 @@ func (self *controller) setResolution(width, height int) {
 
 func (self *controller) setBestFitRenderSize(width, height int) {
 	if self.inDraw {
-		panic("can't change resolution during draw stage")
+		self.reportStageMisuse("can't change resolution during draw stage")
+		return
 	}
 	if width < 1 || height < 1 {
-		panic("game resolution must be at least (1, 1)")
+		self.reportMisuse("game resolution must be at least (1, 1)")
+		return
 	}
 	if self.stretchingEnabled && self.keepAspectRatio &&
 		(width != int(self.bestFitRenderSize.X) || height != int(self.bestFitRenderSize.Y)) {
@@ -304,10 +546,12 @@ func (self *controller) setBestFitRenderSize(width, height int) {
 
 func (self *controller) setBestFitContextSize(width, height int) {
 	if self.inDraw {
-		panic("can't change resolution during draw stage")
+		self.reportStageMisuse("can't change resolution during draw stage")
+		return
 	}
 	if width < 1 || height < 1 {
-		panic("game resolution must be at least (1, 1)")
+		self.reportMisuse("game resolution must be at least (1, 1)")
+		return
 	}
 	if self.stretchingEnabled && self.keepAspectRatio &&
 		(width != int(self.bestFitContextSize.X) || height != int(self.bestFitContextSize.Y)) {
@@ -320,24 +564,86 @@ func (self *controller) setBestFitContextSize(width, height int) {
 
 func (self *controller) scalingSetFilter(filter ScalingFilter) {
 	if self.inDraw {
-		panic("can't change scaling filter during draw stage")
+		self.reportStageMisuse("can't change scaling filter during draw stage")
+		return
 	}
-	if filter != self.scalingFilter {
+	self.setFilterAndWidth(filter, 1.0)
+}
+
+// shared by scalingSetFilter and scalingSetQuality, the caller
+// must have already checked that we are not in the draw stage
+func (self *controller) setFilterAndWidth(filter ScalingFilter, aaWidth float64) {
+	self.crossfadeDuration = 0
+	if filter != self.scalingFilter || aaWidth != self.aaWidth {
 		self.needsRedraw = true
 		self.scalingFilter = filter
+		self.aaWidth = aaWidth
 	}
-	if self.shaders[filter] == nil {
-		self.compileShader(filter)
-	}
+	self.ensureFilterCompiled()
+}
+
+func (self *controller) scalingOnShaderError(handler func(ScalingFilter, error)) {
+	self.shaderErrorHandler = handler
 }
 
 func (self *controller) scalingGetFilter() ScalingFilter {
 	return self.scalingFilter
 }
 
+// Filters available through the quality knob, sorted by softness.
+// Filters with the same softness as a previous one are skipped.
+var pkgQualityFilters []ScalingFilter
+
+func init() {
+	for filter := range scalingFilterEndSentinel {
+		sameSoftness := func(other ScalingFilter) bool { return other.Softness() == filter.Softness() }
+		if !slices.ContainsFunc(pkgQualityFilters, sameSoftness) {
+			pkgQualityFilters = append(pkgQualityFilters, filter)
+		}
+	}
+	slices.SortStableFunc(pkgQualityFilters, func(a, b ScalingFilter) int {
+		return cmp.Compare(a.Softness(), b.Softness())
+	})
+}
+
+// Picks the sharpest filter with a softness >= level, and narrows
+// its AA width so intermediate levels look sharper than the filter
+// itself. All filters except Nearest interpolate between texels
+// over a width given by the relative texture unit uniforms, and
+// shrinking it towards zero converges to nearest sampling, so the
+// softness is approximately proportional to the width.
+func (self *controller) scalingSetQuality(level float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change scaling quality during draw stage")
+		return
+	}
+	if level < 0.0 || level > 1.0 || math.IsNaN(level) {
+		self.reportMisuse("quality level must be in [0, 1] range")
+		return
+	}
+	index, _ := slices.BinarySearchFunc(pkgQualityFilters, level, func(filter ScalingFilter, level float64) int {
+		return cmp.Compare(filter.Softness(), level)
+	})
+	filter := pkgQualityFilters[min(index, len(pkgQualityFilters)-1)]
+	aaWidth := 1.0
+	if softness := filter.Softness(); softness > 0.0 && level < softness {
+		aaWidth = level / softness
+	}
+	self.setFilterAndWidth(filter, aaWidth)
+}
+
+func (self *controller) scalingCurrentUniforms() map[string]any {
+	uniforms := make(map[string]any, len(self.shaderOpts.Uniforms))
+	for key, value := range self.shaderOpts.Uniforms {
+		uniforms[key] = value
+	}
+	return uniforms
+}
+
 func (self *controller) scalingSetStretchingAllowed(allowed, keepAspectRatio, dynamicScaling bool) {
 	if self.inDraw {
-		panic("can't change stretching mode during draw stage")
+		self.reportStageMisuse("can't change stretching mode during draw stage")
+		return
 	}
 	if allowed != self.stretchingEnabled {
 		self.needsRedraw = true
@@ -354,11 +660,137 @@ func (self *controller) scalingGetStretchingAllowed() bool {
 	return self.stretchingEnabled
 }
 
+func (self *controller) scalingSetTightCanvas(tight bool) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change tight canvas mode during draw stage")
+		return
+	}
+	if tight != self.tightCanvas {
+		self.needsRedraw = true
+		self.tightCanvas = tight
+		self.updateCameraArea()
+	}
+}
+
+func (self *controller) scalingGetTightCanvas() bool {
+	return self.tightCanvas
+}
+
+func (self *controller) scalingSetFixedLogicalCanvas(fixed bool) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change fixed logical canvas mode during draw stage")
+		return
+	}
+	if fixed != self.fixedLogicalCanvas {
+		self.needsRedraw = true
+		self.fixedLogicalCanvas = fixed
+		self.updateCameraArea()
+	}
+}
+
+func (self *controller) scalingGetFixedLogicalCanvas() bool {
+	return self.fixedLogicalCanvas
+}
+
+func (self *controller) scalingSetOrientation(orientation Orientation) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change orientation during draw stage")
+		return
+	}
+	if orientation >= orientationEndSentinel {
+		self.reportMisuse("invalid orientation")
+		return
+	}
+	if orientation != self.orientation {
+		self.orientation = orientation
+		self.layoutHasChanged = true
+		self.layoutGeneration += 1
+		self.needsRedraw = true
+		self.needsClear = true
+		self.updateCameraArea()
+	}
+}
+
+func (self *controller) registerDeviceScale(scale float64) {
+	if scale != self.deviceScale {
+		if self.deviceScale != 0.0 {
+			self.deviceScaleChanged = true
+		}
+		self.deviceScale = scale
+	}
+}
+
+func (self *controller) scalingDeviceScaleChanged() bool {
+	return self.deviceScaleChanged
+}
+
+func (self *controller) scalingDeviceScale() float64 {
+	if self.deviceScale == 0.0 {
+		return ebiten.Monitor().DeviceScaleFactor()
+	}
+	return self.deviceScale
+}
+
+func (self *controller) scalingGetOrientation() Orientation {
+	return self.orientation
+}
+
+// Returns whether the orientation swaps the logical width and
+// height when displayed on the screen.
+func (self *controller) orientationSwapsAxes() bool {
+	return self.orientation == Orientation90 || self.orientation == Orientation270
+}
+
+func (self *controller) scalingSetMinimizedBehavior(skipDraw bool) {
+	self.skipDegenerateDraws = skipDraw
+}
+
+func (self *controller) scalingSetFastIntegerPath(enabled bool) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change fast integer path during draw stage")
+		return
+	}
+	self.fastIntegerPath = enabled
+}
+
+func (self *controller) scalingGetFastIntegerPath() bool {
+	return self.fastIntegerPath
+}
+
+func (self *controller) scalingSetViewportScale(factor float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change viewport scale during draw stage")
+		return
+	}
+	if factor <= 0.0 || math.IsNaN(factor) || math.IsInf(factor, 0) {
+		self.reportMisuse("viewport scale must be finite and strictly positive")
+		return
+	}
+	if factor != self.viewportScale {
+		self.viewportScale = factor
+		self.needsRedraw = true
+	}
+}
+
+func (self *controller) scalingGetViewportScale() float64 {
+	return self.viewportScale
+}
+
+// Returns the logical aspect ratio as displayed on the screen,
+// taking orientation into account.
+func (self *controller) displayAspectRatio() float64 {
+	if self.orientationSwapsAxes() {
+		return float64(self.logicalHeight) / float64(self.logicalWidth)
+	}
+	return float64(self.logicalWidth) / float64(self.logicalHeight)
+}
+
 // --- redraw ---
 
 func (self *controller) redrawSetManaged(managed bool) {
 	if self.inDraw {
-		panic("can't change redraw management during draw stage")
+		self.reportStageMisuse("can't change redraw management during draw stage")
+		return
 	}
 	self.redrawManaged = managed
 }
@@ -369,28 +801,107 @@ func (self *controller) redrawIsManaged() bool {
 
 func (self *controller) redrawRequest() {
 	if self.inDraw {
-		panic("can't request redraw during draw stage")
+		self.reportStageMisuse("can't request redraw during draw stage")
+		return
 	}
 	self.needsRedraw = true
 }
 
+func (self *controller) redrawRequestArea(area image.Rectangle) {
+	if self.inDraw {
+		self.reportStageMisuse("can't request redraw during draw stage")
+		return
+	}
+	if area.Empty() {
+		return
+	}
+	self.dirtyRects = append(self.dirtyRects, area)
+}
+
 func (self *controller) redrawPending() bool {
-	return self.needsRedraw || !self.redrawManaged
+	return self.needsRedraw || len(self.dirtyRects) > 0 || !self.redrawManaged
 }
 
 func (self *controller) redrawScheduleClear() {
 	self.needsClear = true
 }
 
+func (self *controller) redrawBeginSceneChange() {
+	if self.inDraw {
+		self.reportStageMisuse("can't begin scene change during draw stage")
+		return
+	}
+	self.needsClear = true
+	self.needsRedraw = true
+}
+
 // --- hi res ---
 
+func (self *controller) hiResActiveBounds() image.Rectangle {
+	return self.activeHiResBounds
+}
+
+func (self *controller) hiResAnchorPoint(anchor Anchor, marginX, marginY int) (float64, float64) {
+	if anchor >= anchorEndSentinel {
+		self.reportMisuse("invalid Anchor")
+		return 0, 0
+	}
+
+	bounds := self.activeHiResBounds
+	minX, minY := float64(bounds.Min.X), float64(bounds.Min.Y)
+	maxX, maxY := float64(bounds.Max.X), float64(bounds.Max.Y)
+	mx, my := float64(marginX), float64(marginY)
+
+	var x, y float64
+	switch anchor % 3 { // column
+	case 0:
+		x = minX + mx
+	case 1:
+		x = (minX+maxX)/2.0 + mx
+	case 2:
+		x = maxX - mx
+	}
+	switch anchor / 3 { // row
+	case 0:
+		y = minY + my
+	case 1:
+		y = (minY+maxY)/2.0 + my
+	case 2:
+		y = maxY - my
+	}
+	return x, y
+}
+
 func (self *controller) hiResDraw(target, source *ebiten.Image, transform *ebimath.Transform) {
 	if !self.inDraw {
-		panic("can't mipix.HiRes().Draw() outside draw stage")
+		self.reportStageMisuse("can't mipix.HiRes().Draw() outside draw stage")
+		return
 	}
 	self.internalHiResDraw(target, source, transform)
 }
 
+func (self *controller) hiResDrawScreen(target, source *ebiten.Image, screenX, screenY float64) {
+	if !self.inDraw {
+		self.reportStageMisuse("can't mipix.HiRes().DrawScreen() outside draw stage")
+		return
+	}
+	targetBounds := target.Bounds()
+	var opts ebiten.DrawImageOptions
+	opts.GeoM.Translate(float64(targetBounds.Min.X)+screenX, float64(targetBounds.Min.Y)+screenY)
+	target.DrawImage(source, &opts)
+}
+
+func (self *controller) hiResSnapToDevicePixels(x, y float64) (float64, float64) {
+	// the high resolution canvas matches device pixels except
+	// when stretching with aspect ratio, where the layout size
+	// is used directly and the device scale must be applied
+	if self.stretchingEnabled && self.keepAspectRatio {
+		scale := self.scalingDeviceScale()
+		return math.Round(x*scale) / scale, math.Round(y*scale) / scale
+	}
+	return math.Round(x), math.Round(y)
+}
+
 func (self *controller) hiResFillOverRect(target *ebiten.Image, minX, minY, maxX, maxY float64, fillColor color.Color) {
 	targetBounds := target.Bounds()
 	targetWidth, targetHeight := float64(targetBounds.Dx()), float64(targetBounds.Dy())
@@ -403,6 +914,30 @@ func (self *controller) hiResFillOverRect(target *ebiten.Image, minX, minY, maxX
 	internal.FillOverRectF32(target, xl, yt, xr, yb, fillColor)
 }
 
+func (self *controller) hiResDrawMarker(target *ebiten.Image, worldX, worldY float64, size float64, clr color.Color) {
+	targetBounds := target.Bounds()
+	targetWidth, targetHeight := float64(targetBounds.Dx()), float64(targetBounds.Dy())
+	cx, cy := self.logicalToHiResCanvasCoords(worldX, worldY, targetWidth, targetHeight)
+	cx += float64(targetBounds.Min.X)
+	cy += float64(targetBounds.Min.Y)
+
+	camMinX, camMinY, camMaxX, camMaxY := self.cameraAreaF64()
+	xScale := targetWidth / (camMaxX - camMinX)
+	yScale := targetHeight / (camMaxY - camMinY)
+	halfWidth, halfHeight := size*xScale/2.0, size*yScale/2.0
+	halfThickness := max(math.Round(min(xScale, yScale)/2.0), 1.0) / 2.0
+
+	internal.FillOverRectF32(target,
+		float32(cx-halfWidth), float32(cy-halfThickness),
+		float32(cx+halfWidth), float32(cy+halfThickness), clr)
+	internal.FillOverRectF32(target,
+		float32(cx-halfThickness), float32(cy-halfHeight),
+		float32(cx+halfThickness), float32(cy-halfThickness), clr)
+	internal.FillOverRectF32(target,
+		float32(cx-halfThickness), float32(cy+halfThickness),
+		float32(cx+halfThickness), float32(cy+halfHeight), clr)
+}
+
 // TODO: something like this is not only important, but should be exposed directly,
 // both for low res and high res, honestly. Or on Convert().
 func (self *controller) logicalToHiResCanvasCoords(x, y, targetWidth, targetHeight float64) (float64, float64) {
@@ -432,16 +967,14 @@ func (self *controller) internalHiResDraw(target, source *ebiten.Image, transfor
 	}
 
 	// compile shader if necessary
-	if self.shaders[self.scalingFilter] == nil {
-		self.compileShader(self.scalingFilter)
-	}
+	self.ensureFilterCompiled()
 
 	// set triangle vertex coordinates
 	targetBounds := target.Bounds()
 	targetMinX, targetMinY := float64(targetBounds.Min.X), float64(targetBounds.Min.Y)
 	targetWidth, targetHeight := float64(targetBounds.Dx()), float64(targetBounds.Dy())
-	xFactor := self.zoomCurrent * targetWidth / float64(self.logicalWidth)
-	yFactor := self.zoomCurrent * targetHeight / float64(self.logicalHeight)
+	xFactor := self.effectiveZoom() * targetWidth / float64(self.logicalWidth)
+	yFactor := self.effectiveZoom() * targetHeight / float64(self.logicalHeight)
 	if self.stretchingEnabled && self.keepAspectRatio {
 		if self.stretchingEnabled && self.keepAspectRatio {
 			scale := internal.BestFitFloat(
@@ -485,6 +1018,7 @@ func (self *controller) internalHiResDraw(target, source *ebiten.Image, transfor
 	self.shaderVertices[2].DstY = float32(p2.Y)
 	self.shaderVertices[3].DstX = float32(p3.X)
 	self.shaderVertices[3].DstY = float32(p3.Y)
+	self.transformVertices(targetBounds, 1.0, self.shakerRotation)
 
 	self.shaderVertices[0].SrcX = float32(sourceBounds.Min.X)
 	self.shaderVertices[0].SrcY = float32(sourceBounds.Min.Y)
@@ -496,8 +1030,7 @@ func (self *controller) internalHiResDraw(target, source *ebiten.Image, transfor
 	self.shaderVertices[3].SrcY = self.shaderVertices[2].SrcY
 
 	self.shaderOpts.Images[0] = source
-	self.shaderOpts.Uniforms["SourceRelativeTextureUnitX"] = float32(float64(self.logicalWidth) / targetWidth)
-	self.shaderOpts.Uniforms["SourceRelativeTextureUnitY"] = float32(float64(self.logicalHeight) / targetHeight)
+	self.setTextureUnitUniforms(float32(float64(self.logicalWidth)/targetWidth), float32(float64(self.logicalHeight)/targetHeight))
 	target.DrawTrianglesShader(
 		self.shaderVertices, self.shaderVertIndices,
 		self.shaders[self.scalingFilter], &self.shaderOpts,
@@ -0,0 +1,165 @@
+package mipix
+
+import (
+	"image"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/edwinsyarief/mipix/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestQualityFiltersSortedBySoftness(t *testing.T) {
+	expected := []ScalingFilter{Nearest, AASamplingSharp, AASamplingSoft, Hermite, Bicubic, Bilinear}
+	if !slices.Equal(pkgQualityFilters, expected) {
+		t.Fatalf("expected quality filters %v, got %v", expected, pkgQualityFilters)
+	}
+}
+
+func TestSetQuality(t *testing.T) {
+	tests := []struct {
+		level   float64
+		filter  ScalingFilter
+		aaWidth float64
+	}{
+		{0.0, Nearest, 1.0},
+		{0.125, AASamplingSharp, 0.5},
+		{0.25, AASamplingSharp, 1.0},
+		{0.4, AASamplingSoft, 0.4 / 0.45},
+		{0.65, Hermite, 1.0},
+		{0.9, Bilinear, 0.9},
+		{1.0, Bilinear, 1.0},
+	}
+
+	ctrl := newTestController(320, 180)
+	for _, test := range tests {
+		ctrl.scalingSetQuality(test.level)
+		if ctrl.scalingGetFilter() != test.filter || !almostEqual(ctrl.aaWidth, test.aaWidth) {
+			t.Fatalf("level %.3f: expected %s with AA width %.3f, got %s with %.3f",
+				test.level, test.filter, test.aaWidth, ctrl.scalingGetFilter(), ctrl.aaWidth)
+		}
+	}
+
+	// explicit filters restore the default AA width
+	ctrl.scalingSetQuality(0.5)
+	ctrl.scalingSetFilter(Hermite)
+	if ctrl.aaWidth != 1.0 {
+		t.Fatalf("expected AA width 1.0 after SetFilter, got %.3f", ctrl.aaWidth)
+	}
+}
+
+func TestSetQualityRedrawAndStage(t *testing.T) {
+	ctrl := newTestController(320, 180)
+
+	// same filter, narrower AA width
+	ctrl.scalingSetQuality(0.25)
+	ctrl.needsRedraw = false
+	ctrl.scalingSetQuality(0.125)
+	if !ctrl.needsRedraw {
+		t.Fatalf("expected a redraw after changing the AA width")
+	}
+
+	// rejected during draw
+	ctrl.setPanicPolicy(PolicyIgnore)
+	ctrl.inDraw = true
+	ctrl.scalingSetQuality(0.9)
+	ctrl.inDraw = false
+	if ctrl.scalingGetFilter() != AASamplingSharp || !almostEqual(ctrl.aaWidth, 0.5) {
+		t.Fatalf("expected quality to be unchanged during draw, got %s with %.3f", ctrl.scalingGetFilter(), ctrl.aaWidth)
+	}
+}
+
+// Game that counts its draws.
+type drawCounterGame struct {
+	draws int
+}
+
+func (self *drawCounterGame) Update() error { return nil }
+func (self *drawCounterGame) Draw(*ebiten.Image) {
+	self.draws += 1
+}
+
+func TestActiveHiResCanvasDegenerateSizes(t *testing.T) {
+	ctrl := newTestController(320, 180)
+	full := ebiten.NewImage(4, 4)
+	defer full.Deallocate()
+	for _, rect := range []image.Rectangle{
+		image.Rect(0, 0, 0, 0), image.Rect(0, 0, 0, 4), image.Rect(0, 0, 4, 0),
+	} {
+		canvas := utils.SubImage(full, rect.Min.X, rect.Min.Y, rect.Max.X, rect.Max.Y)
+		active := ctrl.getActiveHiResCanvas(canvas)
+		if active.Bounds() != canvas.Bounds() {
+			t.Fatalf("%v: expected active bounds %v, got %v", rect, canvas.Bounds(), active.Bounds())
+		}
+	}
+
+	// tiny sizes must still produce valid, contained areas
+	for _, rect := range []image.Rectangle{image.Rect(0, 0, 1, 1), image.Rect(0, 0, 1, 4), image.Rect(0, 0, 4, 1)} {
+		canvas := utils.SubImage(full, rect.Min.X, rect.Min.Y, rect.Max.X, rect.Max.Y)
+		active := ctrl.getActiveHiResCanvas(canvas).Bounds()
+		if !active.In(canvas.Bounds()) {
+			t.Fatalf("%v: active bounds %v outside of canvas", rect, active)
+		}
+	}
+}
+
+func TestBestFitZeroLayout(t *testing.T) {
+	ctrl := newTestController(320, 180)
+	ctrl.stretchingEnabled, ctrl.keepAspectRatio = true, true
+	ctrl.trackerCurrentX, ctrl.trackerCurrentY = 160, 90
+	for _, size := range [][2]int{{0, 0}, {0, 720}, {1280, 0}} {
+		ctrl.hiResWidth, ctrl.hiResHeight = size[0], size[1]
+		minX, minY, maxX, maxY := ctrl.cameraAreaF64()
+		for _, value := range []float64{minX, minY, maxX, maxY} {
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				t.Fatalf("layout %v: invalid camera area (%f, %f, %f, %f)", size, minX, minY, maxX, maxY)
+			}
+		}
+		if maxX-minX != 320 || maxY-minY != 180 {
+			t.Fatalf("layout %v: expected 320x180 area, got %fx%f", size, maxX-minX, maxY-minY)
+		}
+	}
+}
+
+func TestConvertZeroLayout(t *testing.T) {
+	ctrl := newTestController(320, 180)
+	ctrl.hiResWidth, ctrl.hiResHeight = 0, 0
+	if xMargin, yMargin := ctrl.hackyGetMargins(); xMargin != 0 || yMargin != 0 {
+		t.Fatalf("expected zero margins, got (%f, %f)", xMargin, yMargin)
+	}
+	if relX, relY := ctrl.convertToRelativeCoords(10, 10); relX != 0.5 || relY != 0.5 {
+		t.Fatalf("expected relative coords (0.5, 0.5), got (%f, %f)", relX, relY)
+	}
+}
+
+func TestSkipDegenerateDraws(t *testing.T) {
+	game := &drawCounterGame{}
+	ctrl := newTestController(320, 180)
+	ctrl.game = game
+	ctrl.redrawSetManaged(true)
+	ctrl.scalingSetMinimizedBehavior(true)
+	ctrl.hiResWidth, ctrl.hiResHeight = 0, 0 // as reported by Layout()
+	ctrl.lastFrameRedrawn = true
+	ctrl.redrawHistory = 1
+
+	canvas := ebiten.NewImage(1, 1) // ebitengine never passes empty canvases
+	defer canvas.Deallocate()
+	ctrl.queuedDraws = append(ctrl.queuedDraws, queuedDraw{})
+	ctrl.Draw(canvas)
+	if game.draws != 0 {
+		t.Fatal("expected game draw to be skipped")
+	}
+	if len(ctrl.queuedDraws) != 0 {
+		t.Fatal("expected queued draws to be discarded")
+	}
+	if ctrl.debugLastFrameRedrawn() || ctrl.redrawHistory != 0b10 {
+		t.Fatalf("expected frame to be recorded as not redrawn (history %b)", ctrl.redrawHistory)
+	}
+	if !ctrl.redrawPending() {
+		t.Fatal("expected redraw to remain pending")
+	}
+	if ctrl.inDraw {
+		t.Fatal("expected draw stage to be over")
+	}
+}
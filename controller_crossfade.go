@@ -0,0 +1,92 @@
+package mipix
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func (self *controller) scalingCrossfadeFilter(to ScalingFilter, duration TicksDuration) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change scaling filter during draw stage")
+		return
+	}
+	from := self.scalingFilter
+	if duration == 0 || to == from || self.shaders[from] == nil {
+		self.scalingSetFilter(to)
+		return
+	}
+
+	self.scalingSetFilter(to)
+	if self.scalingFilter == from { // compilation failed and fell back
+		return
+	}
+	self.crossfadeFrom = from
+	self.crossfadeElapsed = 0
+	self.crossfadeDuration = duration
+}
+
+func (self *controller) scalingIsCrossfading() bool {
+	return self.crossfadeElapsed < self.crossfadeDuration
+}
+
+func (self *controller) updateFilterCrossfade() {
+	if !self.scalingIsCrossfading() {
+		return
+	}
+	self.crossfadeElapsed += TicksDuration(self.tickRate)
+	self.needsRedraw = true
+	if !self.scalingIsCrossfading() && self.crossfadeBuffer != nil {
+		self.crossfadeBuffer.Deallocate()
+		self.crossfadeBuffer = nil
+	}
+}
+
+// Draws the projection triangles previously set up on shaderVertices
+// with the current scaling filter. During a filter crossfade, the
+// previous filter is also rendered into a temporary buffer and
+// blended on top with the remaining alpha.
+func (self *controller) drawProjectionPass(to *ebiten.Image) {
+	to.DrawTrianglesShader(
+		self.shaderVertices, self.shaderVertIndices,
+		self.shaders[self.activeScalingFilter()], &self.shaderOpts,
+	)
+	if !self.scalingIsCrossfading() {
+		return
+	}
+
+	// get buffer
+	bounds := to.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if self.crossfadeBuffer == nil || self.crossfadeBuffer.Bounds().Dx() < width || self.crossfadeBuffer.Bounds().Dy() < height {
+		if self.crossfadeBuffer != nil {
+			self.crossfadeBuffer.Deallocate()
+		}
+		self.crossfadeBuffer = ebiten.NewImage(width, height)
+	} else {
+		self.crossfadeBuffer.Clear()
+	}
+
+	// render previous filter into the buffer
+	ox, oy := float32(bounds.Min.X), float32(bounds.Min.Y)
+	for i := range self.shaderVertices {
+		self.shaderVertices[i].DstX -= ox
+		self.shaderVertices[i].DstY -= oy
+	}
+	self.crossfadeBuffer.DrawTrianglesShader(
+		self.shaderVertices, self.shaderVertIndices,
+		self.shaders[self.crossfadeFrom], &self.shaderOpts,
+	)
+	for i := range self.shaderVertices {
+		self.shaderVertices[i].DstX += ox
+		self.shaderVertices[i].DstY += oy
+	}
+
+	// blend over the target
+	t := float32(self.crossfadeElapsed) / float32(self.crossfadeDuration)
+	var opts ebiten.DrawImageOptions
+	opts.GeoM.Translate(float64(ox), float64(oy))
+	opts.ColorScale.ScaleAlpha(1.0 - t)
+	buffer := self.crossfadeBuffer.SubImage(image.Rect(0, 0, width, height)).(*ebiten.Image)
+	to.DrawImage(buffer, &opts)
+}
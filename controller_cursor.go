@@ -0,0 +1,44 @@
+package mipix
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func (self *controller) cursorSetImage(img *ebiten.Image, hotspotX, hotspotY int) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change cursor image during draw stage")
+		return
+	}
+	self.cursorImage = img
+	self.cursorHotspotX, self.cursorHotspotY = hotspotX, hotspotY
+	self.needsRedraw = true
+}
+
+func (self *controller) cursorGetImage() (*ebiten.Image, int, int) {
+	return self.cursorImage, self.cursorHotspotX, self.cursorHotspotY
+}
+
+// Called after the camera coordinates have been flushed.
+func (self *controller) updateCursor() {
+	if self.cursorImage == nil {
+		return
+	}
+	x, y := self.convertToLogicalCoords(ebiten.CursorPosition())
+	cursorX, cursorY := int(math.Floor(x)), int(math.Floor(y))
+	if cursorX != self.cursorX || cursorY != self.cursorY {
+		self.cursorX, self.cursorY = cursorX, cursorY
+		self.needsRedraw = true
+	}
+}
+
+func (self *controller) drawCursor(activeCanvas *ebiten.Image) {
+	if self.cursorImage == nil {
+		return
+	}
+	var opts ebiten.DrawImageOptions
+	opts.GeoM.Translate(float64(self.cursorX-self.cursorHotspotX), float64(self.cursorY-self.cursorHotspotY))
+	opts.GeoM.Concat(self.convertWorldToScreenGeoM())
+	activeCanvas.DrawImage(self.cursorImage, &opts)
+}
@@ -2,9 +2,17 @@ package mipix
 
 import (
 	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"math/bits"
+	"strconv"
 
+	"github.com/edwinsyarief/mipix/internal"
+	"github.com/edwinsyarief/mipix/utils"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 // not desirable, but let's ignore it for the moment
@@ -13,6 +21,114 @@ func (self *controller) debugDrawf(format string, args ...any) {
 	self.debugInfo = append(self.debugInfo, fmt.Sprintf(format, args...))
 }
 
+func (self *controller) debugMeasureText(text string) (width, height int) {
+	return utils.MeasureText(text)
+}
+
+func (self *controller) debugLastFrameRedrawn() bool {
+	return self.lastFrameRedrawn
+}
+
+func (self *controller) debugSetCorner(corner Corner) {
+	if corner >= cornerEndSentinel {
+		self.reportMisuse("invalid Corner")
+		return
+	}
+	self.debugCorner = corner
+}
+
+func (self *controller) debugSetDrawOrder(beforeHiRes bool) {
+	self.debugBeforeHiRes = beforeHiRes
+}
+
+func (self *controller) debugSetRuler(enabled bool) {
+	self.debugRuler = enabled
+}
+
+func (self *controller) debugSetLenient(lenient bool) {
+	self.debugLenient = lenient
+}
+
+const debugHeatmapCols = 16
+const debugHeatmapRows = 9
+
+func (self *controller) debugSetRedrawHeatmap(enabled bool) {
+	if enabled && !self.debugHeatmap {
+		clear(self.redrawCellHistory[:])
+	}
+	self.debugHeatmap = enabled
+	self.needsRedraw = true
+}
+
+// Shifts the redraw history of each heatmap cell, marking the cells
+// touched by the current frame. Full frame redraws touch all cells,
+// while area requests only touch the cells they overlap.
+func (self *controller) debugRecordRedrawCells(fullRedraw bool) {
+	if !self.debugHeatmap {
+		return
+	}
+	for i := range self.redrawCellHistory {
+		self.redrawCellHistory[i] <<= 1
+	}
+	if self.redrawManaged && !self.needsRedraw {
+		return // frame not redrawn
+	}
+	if fullRedraw {
+		for i := range self.redrawCellHistory {
+			self.redrawCellHistory[i] |= 1
+		}
+		return
+	}
+
+	// map requested logical areas to heatmap cells
+	minX, minY, maxX, maxY := self.cameraAreaF64()
+	cellWidth := (maxX - minX) / debugHeatmapCols
+	cellHeight := (maxY - minY) / debugHeatmapRows
+	for _, rect := range self.dirtyRects {
+		minCol := max(int(math.Floor((float64(rect.Min.X)-minX)/cellWidth)), 0)
+		maxCol := min(int(math.Ceil((float64(rect.Max.X)-minX)/cellWidth)), debugHeatmapCols)
+		minRow := max(int(math.Floor((float64(rect.Min.Y)-minY)/cellHeight)), 0)
+		maxRow := min(int(math.Ceil((float64(rect.Max.Y)-minY)/cellHeight)), debugHeatmapRows)
+		for row := minRow; row < maxRow; row++ {
+			for col := minCol; col < maxCol; col++ {
+				self.redrawCellHistory[row*debugHeatmapCols+col] |= 1
+			}
+		}
+	}
+}
+
+// Tints each heatmap cell based on how many of the last 64 frames
+// redrew it, from blue (rarely redrawn) to red (redrawn every frame).
+func (self *controller) debugDrawRedrawHeatmap(target *ebiten.Image) {
+	if !self.debugHeatmap {
+		return
+	}
+
+	bounds := target.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	for row := range debugHeatmapRows {
+		minY := bounds.Min.Y + row*height/debugHeatmapRows
+		maxY := bounds.Min.Y + (row+1)*height/debugHeatmapRows
+		for col := range debugHeatmapCols {
+			minX := bounds.Min.X + col*width/debugHeatmapCols
+			maxX := bounds.Min.X + (col+1)*width/debugHeatmapCols
+			redraws := bits.OnesCount64(self.redrawCellHistory[row*debugHeatmapCols+col])
+			heat := float64(redraws) / 64.0
+			clr := color.RGBA{
+				R: uint8(heat * 96.0),
+				B: uint8((1.0 - heat) * 96.0),
+				A: 96,
+			}
+			internal.FillOverRect(target, image.Rect(minX, minY, maxX, maxY), clr)
+		}
+	}
+
+	// the current frame is being redrawn, but it
+	// hasn't been registered in the history yet
+	redraws := bits.OnesCount64(self.redrawHistory<<1 | 1)
+	self.debugInfo = append(self.debugInfo, fmt.Sprintf("redraws: %d/64", redraws))
+}
+
 func (self *controller) debugPrintfr(firstTick, lastTick uint64, format string, args ...any) {
 	if self.currentTick >= firstTick && self.currentTick <= lastTick {
 		fmt.Printf(format, args...)
@@ -31,10 +147,16 @@ func (self *controller) debugPrintfk(key ebiten.Key, format string, args ...any)
 	}
 }
 
+func (self *controller) debugPrintfkp(key ebiten.Key, format string, args ...any) {
+	if inpututil.IsKeyJustPressed(key) {
+		fmt.Printf(format, args...)
+	}
+}
+
 // --- internal ---
 
 func (self *controller) debugDrawAll(target *ebiten.Image) {
-	if len(self.debugInfo) == 0 {
+	if len(self.debugInfo) == 0 && !self.debugRuler {
 		return
 	}
 
@@ -57,12 +179,105 @@ func (self *controller) debugDrawAll(target *ebiten.Image) {
 		}
 	}
 
-	// draw info to offscreen and project
+	// draw ruler and info to offscreen and project
+	textMinX, textMinY := 1, 1
+	if self.debugRuler {
+		textMinX, textMinY = self.debugDrawRuler(self.debugOffscreen.Target())
+	}
 	for i, info := range self.debugInfo {
-		ebitenutil.DebugPrintAt(self.debugOffscreen.Target(), info, 1, 1+i*12)
+		x, y := textMinX, textMinY+i*12
+		switch self.debugCorner {
+		case TopRight:
+			x = offWidth - 1 - len(info)*6
+		case BottomLeft:
+			y = offHeight - 17 - (len(self.debugInfo)-1-i)*12
+		case BottomRight:
+			x = offWidth - 1 - len(info)*6
+			y = offHeight - 17 - (len(self.debugInfo)-1-i)*12
+		}
+		ebitenutil.DebugPrintAt(self.debugOffscreen.Target(), info, x, y)
 	}
 	self.debugOffscreen.Project(target)
 
 	// clear debug info
 	self.debugInfo = self.debugInfo[:0]
 }
+
+const debugRulerMinSpacing = 40.0 // in debug offscreen pixels
+const debugRulerTopHeight = 14
+
+var debugRulerBackColor = color.RGBA{0, 0, 0, 144}
+var debugRulerTickColor = color.RGBA{255, 255, 255, 255}
+
+// Draws tick marks and labels for the logical coordinates of the
+// camera area along the top and left edges of the given canvas.
+// Returns the coordinates where other debug info can start being
+// drawn without overlapping the ruler.
+func (self *controller) debugDrawRuler(canvas *ebiten.Image) (int, int) {
+	bounds := canvas.Bounds()
+	width, height := float64(bounds.Dx()), float64(bounds.Dy())
+	minX, minY, maxX, maxY := self.cameraAreaF64()
+	xPixelsPerUnit := width / (maxX - minX)
+	yPixelsPerUnit := height / (maxY - minY)
+	xStep := debugRulerStep(xPixelsPerUnit)
+	yStep := debugRulerStep(yPixelsPerUnit)
+
+	// determine left strip width based on the widest label
+	firstY, lastY := int(math.Ceil(minY/float64(yStep))), int(math.Floor(maxY/float64(yStep)))
+	maxChars := max(len(strconv.Itoa(firstY*yStep)), len(strconv.Itoa(lastY*yStep)))
+	leftWidth := maxChars*6 + 6
+
+	// background strips
+	internal.FillOverRect(canvas, image.Rect(0, 0, bounds.Dx(), debugRulerTopHeight), debugRulerBackColor)
+	internal.FillOverRect(canvas, image.Rect(0, debugRulerTopHeight, leftWidth, bounds.Dy()), debugRulerBackColor)
+
+	// horizontal ruler
+	xMinor := max(xStep/2, 1)
+	for i := int(math.Ceil(minX / float64(xMinor))); float64(i*xMinor) <= maxX; i++ {
+		value := i * xMinor
+		x := int((float64(value) - minX) * xPixelsPerUnit)
+		if x < leftWidth {
+			continue
+		}
+		if value%xStep == 0 {
+			internal.FillOverRect(canvas, image.Rect(x, debugRulerTopHeight-6, x+1, debugRulerTopHeight), debugRulerTickColor)
+			ebitenutil.DebugPrintAt(canvas, strconv.Itoa(value), x+2, -2)
+		} else {
+			internal.FillOverRect(canvas, image.Rect(x, debugRulerTopHeight-3, x+1, debugRulerTopHeight), debugRulerTickColor)
+		}
+	}
+
+	// vertical ruler
+	yMinor := max(yStep/2, 1)
+	for i := int(math.Ceil(minY / float64(yMinor))); float64(i*yMinor) <= maxY; i++ {
+		value := i * yMinor
+		y := int((float64(value) - minY) * yPixelsPerUnit)
+		if y < debugRulerTopHeight {
+			continue
+		}
+		if value%yStep == 0 {
+			internal.FillOverRect(canvas, image.Rect(leftWidth-5, y, leftWidth, y+1), debugRulerTickColor)
+			if y >= debugRulerTopHeight+6 {
+				ebitenutil.DebugPrintAt(canvas, strconv.Itoa(value), 1, y-8)
+			}
+		} else {
+			internal.FillOverRect(canvas, image.Rect(leftWidth-3, y, leftWidth, y+1), debugRulerTickColor)
+		}
+	}
+
+	return leftWidth + 1, debugRulerTopHeight + 1
+}
+
+// Returns the smallest "nice" integer step (1, 2, 5, 10, 20, 50...)
+// that keeps ruler ticks at least debugRulerMinSpacing pixels apart.
+func debugRulerStep(pixelsPerUnit float64) int {
+	step := 1
+	for {
+		for _, multiplier := range [3]int{1, 2, 5} {
+			if float64(step*multiplier)*pixelsPerUnit >= debugRulerMinSpacing {
+				return step * multiplier
+			}
+		}
+		step *= 10
+	}
+}
@@ -0,0 +1,47 @@
+package mipix
+
+import (
+	"image"
+	"testing"
+)
+
+func TestRedrawHeatmapCells(t *testing.T) {
+	ctrl := newTestController(160, 90) // 10x10 logical pixels per cell
+	ctrl.debugSetRedrawHeatmap(true)
+	ctrl.redrawSetManaged(true)
+	ctrl.trackerCurrentX, ctrl.trackerCurrentY = 80, 45 // area from (0, 0) to (160, 90)
+	ctrl.updateCameraArea()
+
+	// area redraw only heats up the overlapping cells
+	ctrl.redrawRequestArea(image.Rect(15, 5, 25, 10))
+	ctrl.needsRedraw = true // as set by Draw() when areas are pending
+	ctrl.debugRecordRedrawCells(false)
+	for row := range debugHeatmapRows {
+		for col := range debugHeatmapCols {
+			expected := uint64(0)
+			if row == 0 && (col == 1 || col == 2) {
+				expected = 1
+			}
+			if got := ctrl.redrawCellHistory[row*debugHeatmapCols+col]; got != expected {
+				t.Fatalf("cell (%d, %d): expected history %b, got %b", col, row, expected, got)
+			}
+		}
+	}
+
+	// frames without redraws shift in zeros
+	ctrl.needsRedraw = false
+	ctrl.dirtyRects = ctrl.dirtyRects[:0]
+	ctrl.debugRecordRedrawCells(false)
+	if got := ctrl.redrawCellHistory[1]; got != 0b10 {
+		t.Fatalf("expected history 10, got %b", got)
+	}
+
+	// full redraws heat up all cells
+	ctrl.needsRedraw = true
+	ctrl.debugRecordRedrawCells(true)
+	for i, history := range ctrl.redrawCellHistory {
+		if history&1 == 0 {
+			t.Fatalf("cell %d not marked on full redraw", i)
+		}
+	}
+}
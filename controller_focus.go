@@ -0,0 +1,99 @@
+package mipix
+
+import (
+	"math"
+
+	ebimath "github.com/edwinsyarief/ebi-math"
+	"github.com/edwinsyarief/mipix/internal"
+)
+
+// Scripted camera move used by [AccessorCamera.FocusOn](). While
+// active, its tracker and zoomer take precedence over the regular
+// ones, so position and zoom are driven by the same progress value
+// and always arrive together.
+type focusPull struct {
+	active            bool
+	fromX, fromY      float64
+	toX, toY          float64
+	fromZoom, toZoom  float64
+	easing            func(t float64) float64
+	elapsed, duration TicksDuration
+}
+
+func (self *focusPull) Progress() float64 {
+	if self.elapsed >= self.duration {
+		return 1.0
+	}
+	t := float64(self.elapsed) / float64(self.duration)
+	if self.easing != nil {
+		t = self.easing(t)
+	}
+	return t
+}
+
+type focusPullTracker struct{ pull *focusPull }
+
+func (self focusPullTracker) Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY float64) (float64, float64) {
+	t := self.pull.Progress()
+	x := internal.LinearInterp(self.pull.fromX, self.pull.toX, t)
+	y := internal.LinearInterp(self.pull.fromY, self.pull.toY, t)
+	return x - currentX, y - currentY
+}
+
+type focusPullZoomer struct{ pull *focusPull }
+
+func (self focusPullZoomer) Reset() {}
+func (self focusPullZoomer) Update(currentZoom, targetZoom float64) float64 {
+	// interpolating in log space keeps the perceived zoom speed uniform
+	t := self.pull.Progress()
+	from, to := self.pull.fromZoom, self.pull.toZoom
+	return from*math.Pow(to/from, t) - currentZoom
+}
+
+func (self *controller) cameraFocusOn(x, y, zoom float64, duration TicksDuration, easing func(t float64) float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't FocusOn during draw stage")
+		return
+	}
+	if zoom < 0.05 || zoom > 500.0 {
+		self.reportMisuse("FocusOn zoom must be in [0.05, 500.0] range")
+		return
+	}
+	zoom = ebimath.Clamp(zoom, self.zoomLimitMin, self.zoomLimitMax)
+	self.focus = focusPull{
+		active:   true,
+		fromX:    self.trackerCurrentX,
+		fromY:    self.trackerCurrentY,
+		toX:      x,
+		toY:      y,
+		fromZoom: self.zoomCurrent,
+		toZoom:   zoom,
+		easing:   easing,
+		duration: duration,
+	}
+	self.trackerTargetX, self.trackerTargetY = x, y
+	self.zoomTarget = zoom
+	self.zoomAnchor.active = false
+}
+
+func (self *controller) cameraIsFocusing() bool {
+	return self.focus.active
+}
+
+// Advances the focus pull. Called before zoom and tracking updates.
+func (self *controller) updateFocusPull() {
+	if !self.focus.active {
+		return
+	}
+	self.focus.elapsed = min(self.focus.elapsed+TicksDuration(self.tickRate), self.focus.duration)
+}
+
+// Deactivates the focus pull once it's done, handing control back
+// to the regular tracker and zoomer. Called after tracking updates.
+func (self *controller) finishFocusPull() {
+	if !self.focus.active || self.focus.elapsed < self.focus.duration {
+		return
+	}
+	self.focus.active = false
+	self.cameraGetInternalZoomer().Reset()
+}
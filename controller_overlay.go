@@ -0,0 +1,142 @@
+package mipix
+
+import (
+	"image/color"
+
+	"github.com/edwinsyarief/mipix/internal"
+	"github.com/edwinsyarief/mipix/tween"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func (self *controller) cameraStartFade(fadeColor color.Color, toAlpha float64, duration TicksDuration, easing func(t float64) float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't StartFade during draw stage")
+		return
+	}
+	if fadeColor == nil {
+		self.reportMisuse("fade color can't be nil")
+		return
+	}
+	if toAlpha < 0.0 || toAlpha > 1.0 {
+		self.reportMisuse("fade alpha must be in [0, 1] range")
+		return
+	}
+
+	self.fadeColor = fadeColor
+	self.fadeTween = tween.Tween{From: self.fadeAlpha, To: toAlpha, Duration: duration, Easing: easing}
+	self.fadeAlpha = self.fadeTween.Value()
+	self.needsRedraw = true
+}
+
+func (self *controller) cameraGetFadeAlpha() float64 {
+	return self.fadeAlpha
+}
+
+func (self *controller) cameraIsFading() bool {
+	return !self.fadeTween.Done()
+}
+
+func (self *controller) cameraAddOverlay(clr color.Color, in, hold, out TicksDuration) OverlayID {
+	if self.inDraw {
+		self.reportStageMisuse("can't AddOverlay during draw stage")
+		return 0
+	}
+	if clr == nil {
+		self.reportMisuse("overlay color can't be nil")
+		return 0
+	}
+
+	self.overlayNextID += 1
+	self.overlays = append(self.overlays, overlay{
+		id: self.overlayNextID, color: clr,
+		in: in, hold: hold, out: out,
+	})
+	self.needsRedraw = true
+	return self.overlayNextID
+}
+
+func (self *controller) cameraCancelOverlay(id OverlayID) bool {
+	if self.inDraw {
+		self.reportStageMisuse("can't CancelOverlay during draw stage")
+		return false
+	}
+	for i := range self.overlays {
+		if self.overlays[i].id == id {
+			self.overlays = append(self.overlays[:i], self.overlays[i+1:]...)
+			self.needsRedraw = true
+			return true
+		}
+	}
+	return false
+}
+
+func (self *controller) updateOverlays() {
+	// update fade
+	if !self.fadeTween.Done() {
+		self.fadeAlpha = self.fadeTween.Update()
+		self.needsRedraw = true
+	}
+
+	// update overlays, removing the finished ones
+	var kept int
+	for i := range self.overlays {
+		self.overlays[i].elapsed += TicksDuration(self.tickRate)
+		if !self.overlays[i].IsDone() {
+			self.overlays[kept] = self.overlays[i]
+			kept += 1
+		}
+	}
+	if len(self.overlays) > 0 {
+		self.needsRedraw = true
+	}
+	clear(self.overlays[kept:])
+	self.overlays = self.overlays[:kept]
+}
+
+// Overlays are drawn in the order they were added, and the
+// fade goes last, as it's typically used for scene transitions.
+func (self *controller) drawOverlays(activeCanvas *ebiten.Image) {
+	for i := range self.overlays {
+		drawOverlayColor(activeCanvas, self.overlays[i].color, self.overlays[i].Alpha())
+	}
+	if self.fadeColor != nil {
+		drawOverlayColor(activeCanvas, self.fadeColor, self.fadeAlpha)
+	}
+}
+
+func drawOverlayColor(activeCanvas *ebiten.Image, clr color.Color, alpha float64) {
+	if alpha <= 0.0 {
+		return
+	}
+	r, g, b, a := clr.RGBA()
+	scale := func(channel uint32) uint16 { return uint16(float64(channel) * alpha) }
+	internal.FillOver(activeCanvas, color.RGBA64{scale(r), scale(g), scale(b), scale(a)})
+}
+
+type overlay struct {
+	id      OverlayID
+	color   color.Color
+	in      TicksDuration
+	hold    TicksDuration
+	out     TicksDuration
+	elapsed TicksDuration
+}
+
+func (self *overlay) IsDone() bool {
+	return self.elapsed >= self.in+self.hold+self.out
+}
+
+func (self *overlay) Alpha() float64 {
+	if self.elapsed < self.in {
+		return float64(self.elapsed) / float64(self.in)
+	}
+	elapsed := self.elapsed - self.in
+	if elapsed < self.hold {
+		return 1.0
+	}
+	elapsed -= self.hold
+	if elapsed >= self.out {
+		return 0.0
+	}
+	return 1.0 - float64(elapsed)/float64(self.out)
+}
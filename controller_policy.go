@@ -0,0 +1,37 @@
+package mipix
+
+import "log"
+
+func (self *controller) setPanicPolicy(policy PanicPolicy) {
+	if policy >= panicPolicyEndSentinel {
+		panic("invalid PanicPolicy")
+	}
+	self.panicPolicy = policy
+}
+
+// Reports a non-fatal misuse of the API according to the current
+// panic policy. Unless the policy is PolicyPanic, callers must
+// return right after reporting, turning the call into a no-op.
+func (self *controller) reportMisuse(msg string) {
+	switch self.panicPolicy {
+	case PolicyPanic:
+		panic(msg)
+	case PolicyLog:
+		log.Printf("mipix: %s", msg)
+	case PolicyIgnore:
+		// nothing
+	default:
+		panic("invalid PanicPolicy")
+	}
+}
+
+// Like reportMisuse, but for calls made at the wrong stage (e.g.
+// zooming during draw). These can be relaxed with debug leniency,
+// in which case they are logged regardless of the panic policy.
+func (self *controller) reportStageMisuse(msg string) {
+	if self.debugLenient {
+		log.Printf("mipix: %s", msg)
+		return
+	}
+	self.reportMisuse(msg)
+}
@@ -1,6 +1,7 @@
 package mipix
 
 import (
+	"image"
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -9,13 +10,12 @@ import (
 // project from a logical canvas to a high resolution one
 func (self *controller) project(from, to *ebiten.Image) {
 	if !self.inDraw {
-		panic("can't project images outside draw stage")
+		self.reportStageMisuse("can't project images outside draw stage")
+		return
 	}
 
 	// compile shader if necessary
-	if self.shaders[self.scalingFilter] == nil {
-		self.compileShader(self.scalingFilter)
-	}
+	self.ensureFilterCompiled()
 
 	// set up vertices
 	dstBounds := to.Bounds()
@@ -38,25 +38,31 @@ func (self *controller) project(from, to *ebiten.Image) {
 	self.shaderVertices[3].SrcX = self.shaderVertices[0].SrcX
 	self.shaderVertices[3].SrcY = self.shaderVertices[2].SrcY
 
+	self.applyOrientation(float64(srcBounds.Dx()), float64(srcBounds.Dy()), dstBounds)
 	self.shaderOpts.Images[0] = from
-	self.shaderOpts.Uniforms["SourceRelativeTextureUnitX"] = float32(srcBounds.Dx()) / float32(dstBounds.Dx())
-	self.shaderOpts.Uniforms["SourceRelativeTextureUnitY"] = float32(srcBounds.Dy()) / float32(dstBounds.Dy())
-	to.DrawTrianglesShader(
-		self.shaderVertices, self.shaderVertIndices,
-		self.shaders[self.scalingFilter], &self.shaderOpts,
-	)
+	self.drawProjectionPass(to)
 	self.shaderOpts.Images[0] = nil
 }
 
 func (self *controller) projectLogical(from, to *ebiten.Image) {
+	cminX, cminY, cmaxX, cmaxY := self.cameraAreaF64()
+	if self.fastIntegerPath && self.viewportScale == 1.0 && self.shakerRotation == 0.0 && self.projectLogicalFast(from, to, self.cameraArea.Min, cminX, cminY, cmaxX, cmaxY) {
+		return
+	}
+	self.projectLogicalArea(from, to, self.cameraArea.Min, cminX, cminY, cmaxX, cmaxY, true)
+}
+
+// project the given area from a logical canvas whose top-left corner
+// corresponds to the given logical origin to the target, compensating
+// the fractional area coordinates
+func (self *controller) projectLogicalArea(from, to *ebiten.Image, origin image.Point, cminX, cminY, cmaxX, cmaxY float64, oriented bool) {
 	if !self.inDraw {
-		panic("can't project images outside draw stage")
+		self.reportStageMisuse("can't project images outside draw stage")
+		return
 	}
 
 	// compile shader if necessary
-	if self.shaders[self.scalingFilter] == nil {
-		self.compileShader(self.scalingFilter)
-	}
+	self.ensureFilterCompiled()
 
 	// set up vertices
 	dstBounds := to.Bounds()
@@ -69,34 +75,125 @@ func (self *controller) projectLogical(from, to *ebiten.Image) {
 	self.shaderVertices[3].DstX = self.shaderVertices[0].DstX
 	self.shaderVertices[3].DstY = self.shaderVertices[2].DstY
 
-	cminX, cminY, cmaxX, cmaxY := self.cameraAreaF64()
-	fractCamMinX := cminX - math.Floor(cminX)
-	fractCamMinY := cminY - math.Floor(cminY)
-	fractCamMaxX := cmaxX - math.Floor(cmaxX)
-	fractCamMaxY := cmaxY - math.Floor(cmaxY)
-	if fractCamMaxX != 0.0 {
-		fractCamMaxX = 1.0 - fractCamMaxX
-	}
-	if fractCamMaxY != 0.0 {
-		fractCamMaxY = 1.0 - fractCamMaxY
-	}
-
+	// the canvas origin corresponds to the given logical
+	// origin, so we only need to offset the area from it
 	srcBounds := from.Bounds()
-	self.shaderVertices[0].SrcX = float32(float64(srcBounds.Min.X) + fractCamMinX)
-	self.shaderVertices[0].SrcY = float32(float64(srcBounds.Min.Y) + fractCamMinY)
-	self.shaderVertices[1].SrcX = float32(float64(srcBounds.Max.X) - fractCamMaxX)
+	srcMinX := float64(srcBounds.Min.X) + cminX - float64(origin.X)
+	srcMinY := float64(srcBounds.Min.Y) + cminY - float64(origin.Y)
+	srcMaxX := float64(srcBounds.Min.X) + cmaxX - float64(origin.X)
+	srcMaxY := float64(srcBounds.Min.Y) + cmaxY - float64(origin.Y)
+	self.shaderVertices[0].SrcX = float32(srcMinX)
+	self.shaderVertices[0].SrcY = float32(srcMinY)
+	self.shaderVertices[1].SrcX = float32(srcMaxX)
 	self.shaderVertices[1].SrcY = self.shaderVertices[0].SrcY
 	self.shaderVertices[2].SrcX = self.shaderVertices[1].SrcX
-	self.shaderVertices[2].SrcY = float32(float64(srcBounds.Max.Y) - fractCamMaxY)
+	self.shaderVertices[2].SrcY = float32(srcMaxY)
 	self.shaderVertices[3].SrcX = self.shaderVertices[0].SrcX
 	self.shaderVertices[3].SrcY = self.shaderVertices[2].SrcY
 
+	srcWidth, srcHeight := srcMaxX-srcMinX, srcMaxY-srcMinY
+	if oriented { // main projection
+		self.applyViewportTransform(dstBounds)
+		self.applyOrientation(srcWidth, srcHeight, dstBounds)
+	} else {
+		self.setTextureUnitUniforms(float32(srcWidth)/float32(dstBounds.Dx()), float32(srcHeight)/float32(dstBounds.Dy()))
+	}
 	self.shaderOpts.Images[0] = from
-	self.shaderOpts.Uniforms["SourceRelativeTextureUnitX"] = float32(srcBounds.Dx()) / float32(dstBounds.Dx())
-	self.shaderOpts.Uniforms["SourceRelativeTextureUnitY"] = float32(srcBounds.Dy()) / float32(dstBounds.Dy())
-	to.DrawTrianglesShader(
-		self.shaderVertices, self.shaderVertIndices,
-		self.shaders[self.scalingFilter], &self.shaderOpts,
-	)
+	self.drawProjectionPass(to)
 	self.shaderOpts.Images[0] = nil
 }
+
+// attempts to project the given area with a plain nearest DrawImage
+// instead of the scaling shader. This is only possible when the
+// nearest filter is active, there's no rotation and the scaling factor
+// is an integer that maps the area exactly to whole screen pixels.
+// Returns false if the fast path can't be used
+func (self *controller) projectLogicalFast(from, to *ebiten.Image, origin image.Point, cminX, cminY, cmaxX, cmaxY float64) bool {
+	if !self.inDraw || self.orientation != Orientation0 {
+		return false
+	}
+	if self.activeScalingFilter() != Nearest || self.scalingIsCrossfading() {
+		return false
+	}
+
+	const epsilon = 0.001
+	dstBounds := to.Bounds()
+	scaleX := float64(dstBounds.Dx()) / (cmaxX - cminX)
+	scaleY := float64(dstBounds.Dy()) / (cmaxY - cminY)
+	scale := math.Round(scaleX)
+	if scale < 1.0 || math.Abs(scaleX-scale) > epsilon || math.Abs(scaleY-scale) > epsilon {
+		return false
+	}
+	offsetX := (cminX - float64(origin.X)) * scale
+	offsetY := (cminY - float64(origin.Y)) * scale
+	if math.Abs(offsetX-math.Round(offsetX)) > epsilon || math.Abs(offsetY-math.Round(offsetY)) > epsilon {
+		return false
+	}
+
+	var opts ebiten.DrawImageOptions
+	opts.GeoM.Scale(scale, scale)
+	opts.GeoM.Translate(float64(dstBounds.Min.X)-math.Round(offsetX), float64(dstBounds.Min.Y)-math.Round(offsetY))
+	opts.Filter = ebiten.FilterNearest
+	to.DrawImage(from, &opts)
+	return true
+}
+
+// scales and rotates the destination vertices around the center
+// of the target bounds, according to the current viewport scale
+// and shake rotation
+func (self *controller) applyViewportTransform(dstBounds image.Rectangle) {
+	self.transformVertices(dstBounds, self.viewportScale, self.shakerRotation)
+}
+
+// scales and rotates the destination vertex coordinates around
+// the center of the given bounds
+func (self *controller) transformVertices(bounds image.Rectangle, scale, rotation float64) {
+	if scale == 1.0 && rotation == 0.0 {
+		return
+	}
+	centerX := float64(bounds.Min.X+bounds.Max.X) / 2.0
+	centerY := float64(bounds.Min.Y+bounds.Max.Y) / 2.0
+	for i := range self.shaderVertices {
+		x, y := transformPoint(
+			float64(self.shaderVertices[i].DstX), float64(self.shaderVertices[i].DstY),
+			centerX, centerY, scale, rotation,
+		)
+		self.shaderVertices[i].DstX = float32(x)
+		self.shaderVertices[i].DstY = float32(y)
+	}
+}
+
+// scales and rotates the given point around the given center
+func transformPoint(x, y, centerX, centerY, scale, rotation float64) (float64, float64) {
+	sin, cos := math.Sincos(rotation)
+	x, y = (x-centerX)*scale, (y-centerY)*scale
+	return centerX + x*cos - y*sin, centerY + x*sin + y*cos
+}
+
+// inverse of transformPoint
+func untransformPoint(x, y, centerX, centerY, scale, rotation float64) (float64, float64) {
+	sin, cos := math.Sincos(-rotation)
+	x, y = x-centerX, y-centerY
+	return centerX + (x*cos-y*sin)/scale, centerY + (x*sin+y*cos)/scale
+}
+
+// rotates the source vertex coordinates according to the current
+// orientation and sets the relative texture unit uniforms
+func (self *controller) applyOrientation(srcWidth, srcHeight float64, dstBounds image.Rectangle) {
+	dstWidth, dstHeight := float32(dstBounds.Dx()), float32(dstBounds.Dy())
+	if self.orientationSwapsAxes() {
+		dstWidth, dstHeight = dstHeight, dstWidth
+	}
+	self.setTextureUnitUniforms(float32(srcWidth)/dstWidth, float32(srcHeight)/dstHeight)
+
+	// vertices are laid out clockwise, so a quarter turn
+	// clockwise is a shift of the source coordinates
+	v := self.shaderVertices
+	for range self.orientation {
+		lastSrcX, lastSrcY := v[3].SrcX, v[3].SrcY
+		v[3].SrcX, v[3].SrcY = v[2].SrcX, v[2].SrcY
+		v[2].SrcX, v[2].SrcY = v[1].SrcX, v[1].SrcY
+		v[1].SrcX, v[1].SrcY = v[0].SrcX, v[0].SrcY
+		v[0].SrcX, v[0].SrcY = lastSrcX, lastSrcY
+	}
+}
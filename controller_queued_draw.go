@@ -1,6 +1,12 @@
 package mipix
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
 
 type queuedDraw struct {
 	hiResFunc   func(*ebiten.Image, *ebiten.Image)
@@ -13,14 +19,41 @@ func (self *queuedDraw) IsHighResolution() bool {
 
 func (self *controller) queueDraw(handler func(*ebiten.Image)) {
 	if !self.inDraw {
-		panic("can't queue draw outside draw stage")
+		self.reportStageMisuse("can't queue draw outside draw stage")
+		return
 	}
 	self.queuedDraws = append(self.queuedDraws, queuedDraw{logicalFunc: handler})
 }
 
 func (self *controller) queueHiResDraw(handler func(*ebiten.Image, *ebiten.Image)) {
 	if !self.inDraw {
-		panic("can't queue draw outside draw stage")
+		self.reportStageMisuse("can't queue draw outside draw stage")
+		return
 	}
 	self.queuedDraws = append(self.queuedDraws, queuedDraw{hiResFunc: handler})
 }
+
+// Returns a human-readable description of the queued draw,
+// including the name of the handler function when available.
+func (self *queuedDraw) String() string {
+	var kind string
+	var handler any
+	if self.IsHighResolution() {
+		kind, handler = "hi-res", self.hiResFunc
+	} else {
+		kind, handler = "logical", self.logicalFunc
+	}
+	fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if fn == nil {
+		return kind
+	}
+	return kind + " " + fn.Name()
+}
+
+func (self *controller) debugDumpDrawQueue() []string {
+	descriptions := make([]string, len(self.debugLastDrawQueue))
+	for i := range self.debugLastDrawQueue {
+		descriptions[i] = "#" + strconv.Itoa(i) + " " + self.debugLastDrawQueue[i].String()
+	}
+	return descriptions
+}
@@ -0,0 +1,48 @@
+package mipix
+
+import (
+	"math"
+
+	"github.com/edwinsyarief/mipix/internal"
+)
+
+type recoil struct {
+	offsetX  float64
+	offsetY  float64
+	elapsed  TicksDuration
+	recovery TicksDuration
+}
+
+func (self *controller) cameraApplyRecoil(dx, dy float64, recovery TicksDuration) {
+	if self.inDraw {
+		self.reportStageMisuse("can't ApplyRecoil during draw stage")
+		return
+	}
+	if math.IsNaN(dx) || math.IsNaN(dy) || math.IsInf(dx, 0) || math.IsInf(dy, 0) {
+		self.reportMisuse("recoil offsets must be finite")
+		return
+	}
+	if recovery == 0 {
+		return // would be recovered before being visible
+	}
+	self.recoils = append(self.recoils, recoil{offsetX: dx, offsetY: dy, recovery: recovery})
+}
+
+// Returns the accumulated recoil offsets for the current update,
+// advancing the recoveries and removing the completed ones.
+func (self *controller) updateRecoil() (offsetX, offsetY float64) {
+	var kept int
+	for i := range self.recoils {
+		r := &self.recoils[i]
+		t := float64(r.elapsed) / float64(r.recovery)
+		offsetX += internal.CubicSmoothstepInterp(r.offsetX, 0.0, t)
+		offsetY += internal.CubicSmoothstepInterp(r.offsetY, 0.0, t)
+		r.elapsed += TicksDuration(self.tickRate)
+		if r.elapsed < r.recovery {
+			self.recoils[kept] = *r
+			kept += 1
+		}
+	}
+	self.recoils = self.recoils[:kept]
+	return offsetX, offsetY
+}
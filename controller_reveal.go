@@ -0,0 +1,72 @@
+package mipix
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/edwinsyarief/mipix/internal"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func (self *controller) cameraSetRevealMask(shape MaskShape, progress float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetRevealMask during draw stage")
+		return
+	}
+	if shape >= maskShapeEndSentinel {
+		self.reportMisuse("invalid MaskShape")
+		return
+	}
+	if progress < 0.0 || progress > 1.0 || math.IsNaN(progress) {
+		self.reportMisuse("reveal mask progress must be in [0, 1] range")
+		return
+	}
+	if shape != self.revealShape || progress != self.revealProgress {
+		self.revealShape = shape
+		self.revealProgress = progress
+		self.needsRedraw = true
+	}
+}
+
+func (self *controller) cameraSetRevealMaskColor(clr color.Color) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetRevealMaskColor during draw stage")
+		return
+	}
+	if clr == nil {
+		self.reportMisuse("reveal mask color can't be nil")
+		return
+	}
+	self.revealColor = clr
+	self.needsRedraw = true
+}
+
+// Covers the hidden part of the active canvas with the reveal color.
+// Progress 1 means fully revealed, so nothing needs to be drawn.
+func (self *controller) drawRevealMask(activeCanvas *ebiten.Image) {
+	if self.revealShape == MaskNone || self.revealProgress >= 1.0 {
+		return
+	}
+
+	clr := self.revealColor
+	if clr == nil {
+		clr = color.Black
+	}
+	bounds := activeCanvas.Bounds()
+	minX, minY := float64(bounds.Min.X), float64(bounds.Min.Y)
+	maxX, maxY := float64(bounds.Max.X), float64(bounds.Max.Y)
+	switch self.revealShape {
+	case MaskCircle:
+		centerX, centerY := (minX+maxX)/2.0, (minY+maxY)/2.0
+		halfDiagonal := math.Hypot(maxX-minX, maxY-minY) / 2.0
+		internal.FillRing(activeCanvas, centerX, centerY, halfDiagonal*self.revealProgress, halfDiagonal+1.0, clr)
+	case MaskWipeHorizontal:
+		fromX := minX + (maxX-minX)*self.revealProgress
+		internal.FillOverRectF32(activeCanvas, float32(fromX), float32(minY), float32(maxX), float32(maxY), clr)
+	case MaskWipeVertical:
+		fromY := minY + (maxY-minY)*self.revealProgress
+		internal.FillOverRectF32(activeCanvas, float32(minX), float32(fromY), float32(maxX), float32(maxY), clr)
+	default:
+		panic("invalid MaskShape")
+	}
+}
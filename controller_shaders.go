@@ -49,15 +49,97 @@ func init() {
 	pkgSrcKageFilters[SrcBilinear] = _srcBilinear
 }
 
-func (self *controller) compileShader(filter ScalingFilter) {
-	var err error
-	self.shaders[filter], err = ebiten.NewShader(pkgSrcKageFilters[filter])
+func (self *controller) compileShader(filter ScalingFilter) error {
+	shader, err := ebiten.NewShader(pkgSrcKageFilters[filter])
 	if err != nil {
-		panic("Failed to compile shader for '" + filter.String() + "' filter: " + err.Error())
+		return err
 	}
+	self.shaders[filter] = shader
 	if self.shaderOpts.Uniforms == nil {
 		self.initShaderProperties()
 	}
+	return nil
+}
+
+// Compiles the shader for the current scaling filter if necessary.
+// If compilation fails and a shader error handler has been set,
+// the handler is notified and we fall back to the Nearest filter.
+func (self *controller) ensureFilterCompiled() {
+	if self.shaders[self.scalingFilter] != nil {
+		return
+	}
+	err := self.compileShader(self.scalingFilter)
+	if err == nil {
+		return
+	}
+	if self.shaderErrorHandler == nil || self.scalingFilter == Nearest {
+		panic("Failed to compile shader for '" + self.scalingFilter.String() + "' filter: " + err.Error())
+	}
+	self.shaderErrorHandler(self.scalingFilter, err)
+	self.scalingFilter = Nearest
+	self.needsRedraw = true
+	self.ensureFilterCompiled()
+}
+
+// Compiles the next filter shader that hasn't been compiled yet.
+// Returns true once no pending shaders remain.
+func (self *controller) scalingCompileIncrementally() bool {
+	if self.inDraw {
+		self.reportStageMisuse("can't compile shaders during draw stage")
+		return false
+	}
+	var compiledOne bool
+	for filter := range scalingFilterEndSentinel {
+		if self.shaders[filter] != nil || self.shaderFailed[filter] {
+			continue
+		}
+		if compiledOne {
+			return false // more shaders pending
+		}
+		compiledOne = true
+		self.compileShaderOrReport(filter)
+	}
+	return true
+}
+
+// Compiles the shader for the given filter if necessary and returns
+// whether it's available. Failures panic if no shader error handler
+// has been set, and otherwise are reported to the handler and
+// remembered, so failed filters are not compiled (nor reported) again.
+func (self *controller) compileShaderOrReport(filter ScalingFilter) bool {
+	if self.shaders[filter] != nil {
+		return true
+	}
+	if self.shaderFailed[filter] {
+		return false
+	}
+	err := self.compileShader(filter)
+	if err != nil {
+		if self.shaderErrorHandler == nil {
+			panic("Failed to compile shader for '" + filter.String() + "' filter: " + err.Error())
+		}
+		self.shaderFailed[filter] = true
+		self.shaderErrorHandler(filter, err)
+		return false
+	}
+	return true
+}
+
+func (self *controller) scalingIsCompiled(filter ScalingFilter) bool {
+	if filter >= scalingFilterEndSentinel {
+		self.reportMisuse("invalid ScalingFilter")
+		return false
+	}
+	return self.shaders[filter] != nil
+}
+
+// Sets the relative texture unit uniforms, which most filters use as
+// the width of the interpolation between texels, adjusted by the AA
+// width of the quality knob.
+func (self *controller) setTextureUnitUniforms(unitX, unitY float32) {
+	aaWidth := float32(self.aaWidth)
+	self.shaderOpts.Uniforms["SourceRelativeTextureUnitX"] = unitX * aaWidth
+	self.shaderOpts.Uniforms["SourceRelativeTextureUnitY"] = unitY * aaWidth
 }
 
 func (self *controller) initShaderProperties() {
@@ -1,6 +1,10 @@
 package mipix
 
-import "github.com/edwinsyarief/mipix/shaker"
+import (
+	"math"
+
+	"github.com/edwinsyarief/mipix/shaker"
+)
 
 type shakerChannel struct {
 	shaker    shaker.Shaker
@@ -10,13 +14,87 @@ type shakerChannel struct {
 	fadeOut   TicksDuration
 	offsetX   float64
 	offsetY   float64
+	rotation  float64
 	wasActive bool
+	boost     shakeBoost
+
+	// triggered shakes can be attenuated, which scales
+	// the activity level by (1.0 - attenuation)
+	attenuation float64
+
+	// optional easings for the fade in and fade out ramps,
+	// applied over the linear activity (nil means linear)
+	fadeInCurve  func(t float64) float64
+	fadeOutCurve func(t float64) float64
+
+	// optional exponent applied to the final activity level
+	// before passing it to the shaker (zero means linear)
+	activityExponent float64
+
+	// axis masks, applied when accumulating offsets
+	maskX bool
+	maskY bool
+}
+
+// Triggered shakes on channels with an indefinite shake in progress
+// don't override the continuous shake. Instead, they are tracked
+// separately as a temporary boost, and the level passed to the
+// shaker becomes the max of both activities. Once the boost ends,
+// the continuous shake simply resumes.
+type shakeBoost struct {
+	elapsed     TicksDuration
+	fadeIn      TicksDuration
+	duration    TicksDuration
+	fadeOut     TicksDuration
+	attenuation float64
 }
 
-func (self *shakerChannel) Trigger(fadeIn, duration, fadeOut TicksDuration) {
+func (self *shakeBoost) IsActive() bool {
+	return self.elapsed < self.fadeIn+self.duration+self.fadeOut
+}
+
+func (self *shakeBoost) Activity() float64 {
+	if self.elapsed < self.fadeIn {
+		return float64(self.elapsed) / float64(self.fadeIn)
+	}
+	elapsed := self.elapsed - self.fadeIn
+	if elapsed < self.duration {
+		return 1.0
+	}
+	elapsed -= self.duration
+	if elapsed >= self.fadeOut {
+		return 0.0
+	}
+	return 1.0 - float64(elapsed)/float64(self.fadeOut)
+}
+
+func (self *shakeBoost) End(fadeOut TicksDuration) {
+	if !self.IsActive() {
+		return
+	}
+	activity := self.Activity()
+	self.fadeIn, self.duration, self.fadeOut = 0, 0, fadeOut
+	self.elapsed = TicksDuration(float64(fadeOut) * (1.0 - activity))
+}
+
+// The scale must be in [0, 1] range, and it's applied
+// to the activity level of the triggered shake.
+func (self *shakerChannel) Trigger(fadeIn, duration, fadeOut TicksDuration, scale float64) {
+	if self.duration == maxUint32 && self.IsShaking() {
+		activity := 0.0
+		if self.boost.IsActive() {
+			activity = self.boost.Activity()
+		}
+		self.boost = shakeBoost{fadeIn: fadeIn, duration: duration, fadeOut: fadeOut}
+		self.boost.elapsed = TicksDuration(float64(fadeIn) * activity)
+		self.boost.attenuation = 1.0 - scale
+		return
+	}
+
 	self.Start(fadeIn)
 	self.duration = duration
-	self.fadeOut = fadeOut // TODO: maybe triggered shakes shouldn't stop pre-existing continuous shakes?
+	self.fadeOut = fadeOut
+	self.attenuation = 1.0 - scale
 }
 
 func (self *shakerChannel) Start(fadeIn TicksDuration) {
@@ -24,14 +102,31 @@ func (self *shakerChannel) Start(fadeIn TicksDuration) {
 		return
 	}
 	activity := self.Activity()
+	self.attenuation = 0.0
 	self.fadeIn = fadeIn
 	self.duration = maxUint32
 	self.fadeOut = 0
 	self.elapsed = TicksDuration(float64(fadeIn) * activity)
 }
 
-// TODO: a couple EnsureShaking(fadeIn, ...channels) and EnsureNotShaking(fadeOut, ...channels).
-//       Or just guarantee that Start and End are safe to use like that, code and document.
+// Starts the shake unless the channel is already shaking
+// indefinitely (either fading in or in full shake).
+func (self *shakerChannel) EnsureShaking(fadeIn TicksDuration) {
+	if self.duration == maxUint32 && self.IsShaking() {
+		return
+	}
+	self.Start(fadeIn)
+}
+
+// Ends the shake unless the channel is already stopped
+// or fading out.
+func (self *shakerChannel) EnsureNotShaking(fadeOut TicksDuration) {
+	if !self.IsShaking() || self.IsFadingOut() {
+		return
+	}
+	self.End(fadeOut)
+}
+
 func (self *shakerChannel) End(fadeOut TicksDuration) {
 	// TODO: I don't like this code at all. going into negative durations,
 	//       modifying elapsed... it's all kinda messy. I would like some
@@ -39,6 +134,7 @@ func (self *shakerChannel) End(fadeOut TicksDuration) {
 	if self.fadeOut == fadeOut && self.IsFadingOut() {
 		return
 	}
+	self.boost.End(fadeOut)
 	activity := self.Activity()
 	self.duration = self.elapsed - self.fadeIn
 	self.fadeOut = fadeOut
@@ -66,30 +162,65 @@ func (self *shakerChannel) IsShaking() bool {
 	}
 }
 
-func (self *shakerChannel) Update(index int, tickRate uint64) {
+// Returns true when the shake has just fully stopped and
+// the shaker has received its termination call.
+func (self *shakerChannel) Update(fallback shaker.Shaker, tickRate uint64) bool {
 	var selfShaker shaker.Shaker = self.shaker
 	if selfShaker == nil {
-		if index != 0 {
-			return
-		}
-		if defaultShaker == nil {
-			defaultShaker = &shaker.Random{}
+		if fallback == nil {
+			return false
 		}
-		selfShaker = defaultShaker
+		selfShaker = fallback
 	}
 
 	if self.IsShaking() {
 		self.wasActive = true
-		activity := self.Activity()
+		activity := self.shapeActivity(self.Activity(), self.elapsed < self.fadeIn)
+		activity *= 1.0 - self.attenuation
+		if self.boost.IsActive() {
+			boostActivity := self.shapeActivity(self.boost.Activity(), self.boost.elapsed < self.boost.fadeIn)
+			activity = max(activity, boostActivity*(1.0-self.boost.attenuation))
+			self.boost.elapsed += TicksDuration(tickRate)
+		}
+		if self.activityExponent != 0.0 && self.activityExponent != 1.0 {
+			activity = math.Pow(activity, self.activityExponent)
+		}
 		self.offsetX, self.offsetY = selfShaker.GetShakeOffsets(activity)
+		if rotShaker, ok := selfShaker.(shaker.RotationalShaker); ok {
+			self.rotation = rotShaker.GetShakeRotation(activity)
+		}
 		self.elapsed += TicksDuration(tickRate)
 	} else if self.wasActive {
 		_, _ = selfShaker.GetShakeOffsets(0.0) // termination call
+		if rotShaker, ok := selfShaker.(shaker.RotationalShaker); ok {
+			_ = rotShaker.GetShakeRotation(0.0) // termination call
+		}
+		self.rotation = 0.0
+		self.boost = shakeBoost{}
 		if self.offsetX != 0.0 || self.offsetY != 0.0 {
 			self.offsetX, self.offsetY = 0.0, 0.0
 		}
 		self.wasActive = false
+		return true
+	}
+	return false
+}
+
+// Applies the fade curves to the given linear activity. Timing
+// computations always use linear activities, so the curves only
+// shape the level passed to the shaker.
+func (self *shakerChannel) shapeActivity(activity float64, fadingIn bool) float64 {
+	if activity <= 0.0 || activity >= 1.0 {
+		return activity
 	}
+	if fadingIn {
+		if self.fadeInCurve != nil {
+			return self.fadeInCurve(activity)
+		}
+	} else if self.fadeOutCurve != nil {
+		return 1.0 - self.fadeOutCurve(1.0-activity)
+	}
+	return activity
 }
 
 func (self *shakerChannel) Activity() float64 {
@@ -110,3 +241,22 @@ func (self *shakerChannel) Activity() float64 {
 		return 1.0 - float64(elapsed)/float64(self.fadeOut)
 	}
 }
+
+// Rescales all timing values by the given factor. Used to preserve
+// wall-clock timings when the tick rate changes. Indefinite durations
+// are preserved as such.
+func (self *shakerChannel) Rescale(factor float64) {
+	rescale := func(ticks TicksDuration) TicksDuration {
+		return TicksDuration(min(math.Round(float64(ticks)*factor), maxUint32-1))
+	}
+	self.elapsed = rescale(self.elapsed)
+	self.fadeIn = rescale(self.fadeIn)
+	if self.duration != maxUint32 {
+		self.duration = rescale(self.duration)
+	}
+	self.fadeOut = rescale(self.fadeOut)
+	self.boost.elapsed = rescale(self.boost.elapsed)
+	self.boost.fadeIn = rescale(self.boost.fadeIn)
+	self.boost.duration = rescale(self.boost.duration)
+	self.boost.fadeOut = rescale(self.boost.fadeOut)
+}
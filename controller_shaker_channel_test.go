@@ -0,0 +1,104 @@
+package mipix
+
+import (
+	"math"
+	"testing"
+)
+
+// Shaker that records the levels it receives.
+type levelRecorder struct {
+	levels []float64
+}
+
+func (self *levelRecorder) GetShakeOffsets(level float64) (float64, float64) {
+	self.levels = append(self.levels, level)
+	return level, level
+}
+
+func (self *levelRecorder) Last() float64 {
+	return self.levels[len(self.levels)-1]
+}
+
+func TestTriggerBoostsIndefiniteShake(t *testing.T) {
+	const FadeIn = 100
+	recorder := &levelRecorder{}
+	channel := shakerChannel{shaker: recorder}
+
+	// start a continuous shake and let it fade in partially
+	channel.Start(FadeIn)
+	for range 20 {
+		channel.Update(nil, 1)
+	}
+	if !almostEqual(recorder.Last(), 19.0/FadeIn) {
+		t.Fatalf("expected continuous level %.2f, got %.2f", 19.0/FadeIn, recorder.Last())
+	}
+
+	// trigger a shake on the busy channel: it must boost the level
+	channel.Trigger(0, 10, 10, 1.0)
+	for i := range 10 {
+		channel.Update(nil, 1)
+		if recorder.Last() != 1.0 {
+			t.Fatalf("boost tick %d: expected level 1.0, got %.2f", i, recorder.Last())
+		}
+	}
+	for range 10 { // boost fade out
+		channel.Update(nil, 1)
+	}
+	if channel.boost.IsActive() {
+		t.Fatal("expected boost to be over")
+	}
+
+	// the continuous shake must resume where it would have been
+	for range 200 {
+		expected := min(float64(channel.elapsed)/FadeIn, 1.0)
+		if channel.Update(nil, 1) {
+			t.Fatal("continuous shake unexpectedly stopped")
+		}
+		if !almostEqual(recorder.Last(), expected) {
+			t.Fatalf("expected continuous level %.2f, got %.2f", expected, recorder.Last())
+		}
+	}
+	if !channel.IsShaking() || channel.duration != maxUint32 {
+		t.Fatal("expected continuous shake to remain indefinite")
+	}
+}
+
+func TestTriggerBoostMaxesWithContinuousLevel(t *testing.T) {
+	recorder := &levelRecorder{}
+	channel := shakerChannel{shaker: recorder}
+	channel.Start(100)
+	for range 50 {
+		channel.Update(nil, 1)
+	}
+
+	// a weaker boost must not lower the continuous level
+	channel.Trigger(0, 10, 0, 0.25)
+	channel.Update(nil, 1)
+	if !almostEqual(recorder.Last(), 0.5) {
+		t.Fatalf("expected level 0.5, got %.2f", recorder.Last())
+	}
+}
+
+func TestTriggerOnIdleChannelEnds(t *testing.T) {
+	recorder := &levelRecorder{}
+	channel := shakerChannel{shaker: recorder}
+	channel.Trigger(0, 10, 10, 1.0)
+
+	var ended bool
+	for range 40 {
+		if channel.Update(nil, 1) {
+			ended = true
+			break
+		}
+	}
+	if !ended {
+		t.Fatal("expected triggered shake to end")
+	}
+	if recorder.Last() != 0.0 {
+		t.Fatalf("expected termination call with level 0, got %.2f", recorder.Last())
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,88 @@
+package mipix
+
+import "github.com/edwinsyarief/mipix/internal"
+
+// Scripted tracker used by [AccessorCamera.SweepTo](). While active,
+// it takes precedence over the regular tracker.
+type sweepTracker struct {
+	active       bool
+	fromX, fromY float64
+	toX, toY     float64
+	anticipation float64
+	elapsed      TicksDuration
+	duration     TicksDuration
+}
+
+// fraction of the sweep duration spent on the wind-up
+const sweepWindUpFraction = 0.3
+
+func (self *sweepTracker) Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY float64) (float64, float64) {
+	self.elapsed = min(self.elapsed+TicksDuration(internal.GetTPU()), self.duration)
+	progress := self.progressAt(self.elapsed)
+	x := internal.LinearInterp(self.fromX, self.toX, progress)
+	y := internal.LinearInterp(self.fromY, self.toY, progress)
+	return x - currentX, y - currentY
+}
+
+// Returns the relative position along the sweep path. During
+// the wind-up, the value goes negative (pulling back), and then
+// it sweeps smoothly towards 1.0.
+func (self *sweepTracker) progressAt(elapsed TicksDuration) float64 {
+	if elapsed >= self.duration {
+		return 1.0
+	}
+	t := float64(elapsed) / float64(self.duration)
+	if self.anticipation == 0.0 {
+		return internal.QuadInOut(t)
+	}
+	if t < sweepWindUpFraction {
+		return -self.anticipation * internal.QuadInOut(t/sweepWindUpFraction)
+	}
+	t = (t - sweepWindUpFraction) / (1.0 - sweepWindUpFraction)
+	return internal.LinearInterp(-self.anticipation, 1.0, internal.QuadInOut(t))
+}
+
+func (self *sweepTracker) Done() bool {
+	return self.elapsed >= self.duration
+}
+
+func (self *controller) cameraSweepTo(x, y float64, anticipation float64, duration TicksDuration) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SweepTo during draw stage")
+		return
+	}
+	if anticipation < 0.0 {
+		self.reportMisuse("SweepTo anticipation can't be negative")
+		return
+	}
+	self.sweep = sweepTracker{
+		active:       true,
+		fromX:        self.trackerCurrentX,
+		fromY:        self.trackerCurrentY,
+		toX:          x,
+		toY:          y,
+		anticipation: anticipation,
+		duration:     duration,
+	}
+	self.trackerTargetX, self.trackerTargetY = x, y
+}
+
+func (self *controller) cameraIsSweeping() bool {
+	return self.sweep.active
+}
+
+func (self *controller) cameraOnArrive(callback func()) {
+	self.sweepOnArrive = callback
+}
+
+// Deactivates the sweep once it's done and notifies the
+// arrival. Called after each tracking update.
+func (self *controller) updateSweep() {
+	if !self.sweep.active || !self.sweep.Done() {
+		return
+	}
+	self.sweep.active = false
+	if self.sweepOnArrive != nil {
+		self.sweepOnArrive()
+	}
+}
@@ -8,12 +8,50 @@ func (self *controller) tickNow() uint64 {
 
 func (self *controller) tickSetRate(rate int) {
 	if rate < 1 || rate > 256 {
-		panic("tick rate must be within [1, 256]")
+		self.reportMisuse("tick rate must be within [1, 256]")
+		return
 	}
 	self.tickRate = uint64(rate)
 	internal.CurrentTPU = self.tickRate // massive hacks for unholy reasons
 }
 
+func (self *controller) tickSetRateRescaled(rate int) {
+	prevRate := self.tickRate
+	self.tickSetRate(rate)
+	if prevRate == self.tickRate {
+		return
+	}
+	factor := float64(self.tickRate) / float64(prevRate)
+	for i := range self.shakerChannels {
+		self.shakerChannels[i].Rescale(factor)
+	}
+}
+
 func (self *controller) tickGetRate() int {
 	return int(self.tickRate)
 }
+
+func (self *controller) tickSetManualStepping(manual bool) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change manual stepping during draw stage")
+		return
+	}
+	self.manualStepping = manual
+}
+
+func (self *controller) tickIsManualStepping() bool {
+	return self.manualStepping
+}
+
+func (self *controller) tickStep() {
+	if self.inDraw {
+		self.reportStageMisuse("can't Step during draw stage")
+		return
+	}
+	if !self.manualStepping {
+		self.reportMisuse("can't Step without manual stepping enabled")
+		return
+	}
+	self.currentTick += self.tickRate
+	self.updateTickEffects()
+}
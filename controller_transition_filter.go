@@ -0,0 +1,68 @@
+package mipix
+
+import ebimath "github.com/edwinsyarief/ebi-math"
+
+// Camera speed, in screens per second, above which the camera
+// is considered to be in transition.
+const transitionFilterSpeedThreshold = 1.0
+
+func (self *controller) scalingSetTransitionFilter(filter ScalingFilter) {
+	if self.inDraw {
+		self.reportStageMisuse("can't change transition filter during draw stage")
+		return
+	}
+	if filter >= scalingFilterEndSentinel {
+		self.reportMisuse("invalid ScalingFilter")
+		return
+	}
+	if !self.compileShaderOrReport(filter) {
+		return
+	}
+	self.transitionFilter = filter
+	self.hasTransitionFilter = true
+	self.needsRedraw = true
+}
+
+func (self *controller) scalingClearTransitionFilter() {
+	if self.inDraw {
+		self.reportStageMisuse("can't change transition filter during draw stage")
+		return
+	}
+	if self.transitionFilterActive {
+		self.needsRedraw = true
+	}
+	self.hasTransitionFilter = false
+	self.transitionFilterActive = false
+}
+
+func (self *controller) scalingGetTransitionFilter() (ScalingFilter, bool) {
+	return self.transitionFilter, self.hasTransitionFilter
+}
+
+// Returns the filter that must be used for the projection.
+func (self *controller) activeScalingFilter() ScalingFilter {
+	if self.transitionFilterActive {
+		return self.transitionFilter
+	}
+	return self.scalingFilter
+}
+
+// Called on every update, after camera coordinates have been flushed.
+func (self *controller) updateTransitionFilter() {
+	active := self.hasTransitionFilter && self.cameraInTransition()
+	if active != self.transitionFilterActive {
+		self.transitionFilterActive = active
+		self.needsRedraw = true
+	}
+}
+
+func (self *controller) cameraInTransition() bool {
+	if self.zoomCurrent != self.zoomTarget {
+		return true
+	}
+	screenWidth := float64(self.logicalWidth) / self.zoomCurrent
+	screenHeight := float64(self.logicalHeight) / self.zoomCurrent
+	speedX := ebimath.Abs(self.trackerPrevSpeedX) / screenWidth
+	speedY := ebimath.Abs(self.trackerPrevSpeedY) / screenHeight
+	return max(speedX, speedY) > transitionFilterSpeedThreshold
+}
@@ -0,0 +1,67 @@
+package mipix
+
+import "github.com/edwinsyarief/mipix/shaker"
+
+func (self *controller) cameraAddTrauma(amount float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't AddTrauma during draw stage")
+		return
+	}
+	self.traumaLevel = min(max(self.traumaLevel+amount, 0.0), 1.0)
+}
+
+func (self *controller) cameraGetTrauma() float64 {
+	return self.traumaLevel
+}
+
+func (self *controller) cameraSetTraumaDecay(perSecond float64) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetTraumaDecay during draw stage")
+		return
+	}
+	if perSecond < 0.0 {
+		self.reportMisuse("trauma decay can't be negative")
+		return
+	}
+	self.traumaDecayOff = perSecond - 1.0
+}
+
+func (self *controller) cameraSetTraumaShaker(traumaShaker shaker.Shaker) {
+	if self.inDraw {
+		self.reportStageMisuse("can't SetTraumaShaker during draw stage")
+		return
+	}
+	if self.traumaActive {
+		_, _ = self.getTraumaShaker().GetShakeOffsets(0.0) // termination call
+		self.traumaActive = false
+	}
+	self.traumaShaker = traumaShaker
+}
+
+func (self *controller) getTraumaShaker() shaker.Shaker {
+	if self.traumaShaker != nil {
+		return self.traumaShaker
+	}
+	if defaultTraumaShaker == nil {
+		defaultTraumaShaker = &shaker.Random{}
+	}
+	return defaultTraumaShaker
+}
+
+// Returns the trauma shake offsets for the current update and
+// decays the trauma level.
+func (self *controller) updateTrauma() (offsetX, offsetY float64) {
+	if self.traumaLevel <= 0.0 {
+		if self.traumaActive {
+			_, _ = self.getTraumaShaker().GetShakeOffsets(0.0) // termination call
+			self.traumaActive = false
+		}
+		return 0.0, 0.0
+	}
+
+	self.traumaActive = true
+	offsetX, offsetY = self.getTraumaShaker().GetShakeOffsets(self.traumaLevel * self.traumaLevel)
+	decay := (self.traumaDecayOff + 1.0) / float64(Tick().UPS())
+	self.traumaLevel = max(self.traumaLevel-decay, 0.0)
+	return offsetX, offsetY
+}
@@ -9,3 +9,4 @@ import (
 var defaultZoomer *zoomer.Quadratic
 var defaultTracker *tracker.SpringTailer
 var defaultShaker *shaker.Random
+var defaultTraumaShaker *shaker.Random
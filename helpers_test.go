@@ -0,0 +1,11 @@
+package mipix
+
+// Returns a controller with the same defaults as pkgController
+// and the given logical resolution, so tests don't depend on the
+// global state.
+func newTestController(logicalWidth, logicalHeight int) *controller {
+	var ctrl controller
+	ctrl.setDefaults()
+	ctrl.setResolution(logicalWidth, logicalHeight)
+	return &ctrl
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Reading pixels back requires the ebitengine main loop, so
+// tests are run from within the first update of a dummy game.
+type testGame struct {
+	m    *testing.M
+	code int
+}
+
+func (self *testGame) Update() error {
+	self.code = self.m.Run()
+	return ebiten.Termination
+}
+
+func (*testGame) Draw(*ebiten.Image) {}
+
+func (*testGame) Layout(int, int) (int, int) {
+	return 320, 240
+}
+
+func TestMain(m *testing.M) {
+	game := &testGame{m: m, code: 1}
+	if err := ebiten.RunGame(game); err != nil {
+		panic(err)
+	}
+	os.Exit(game.code)
+}
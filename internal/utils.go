@@ -94,6 +94,10 @@ func init() {
 	pkgMask1x1.Fill(color.RGBA{255, 255, 255, 255})
 	pkgFillVertices = make([]ebiten.Vertex, 4)
 	pkgFillVertIndices = []uint16{0, 1, 3, 3, 1, 2}
+	// color.Color.RGBA() returns premultiplied values, but ebitengine
+	// interprets vertex colors as straight alpha by default, which
+	// would darken fills with partial alpha
+	pkgFillTrianglesOpts.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
 	for i := range 4 {
 		pkgFillVertices[i].SrcX = 0.5
 		pkgFillVertices[i].SrcY = 0.5
@@ -152,6 +156,61 @@ func FillOverRectF32(target *ebiten.Image, minX, minY, maxX, maxY float32, fillC
 	target.DrawTriangles(pkgFillVertices, pkgFillVertIndices, pkgMask1x1, &pkgFillTrianglesOpts)
 }
 
+var pkgPolygonVertices []ebiten.Vertex
+var pkgPolygonVertIndices []uint16
+
+// Fills a polygon using a triangle fan. Only convex
+// polygons are guaranteed to be filled correctly.
+func FillPolygon(target *ebiten.Image, points []image.Point, fillColor color.Color) {
+	if len(points) < 3 || len(points) > math.MaxUint16 {
+		return
+	}
+
+	r, g, b, a := toRGBAf32(fillColor)
+	pkgPolygonVertices = pkgPolygonVertices[:0]
+	for _, point := range points {
+		pkgPolygonVertices = append(pkgPolygonVertices, ebiten.Vertex{
+			DstX: float32(point.X), DstY: float32(point.Y),
+			SrcX: 0.5, SrcY: 0.5,
+			ColorR: r, ColorG: g, ColorB: b, ColorA: a,
+		})
+	}
+	pkgPolygonVertIndices = pkgPolygonVertIndices[:0]
+	for i := 1; i < len(points)-1; i++ {
+		pkgPolygonVertIndices = append(pkgPolygonVertIndices, 0, uint16(i), uint16(i+1))
+	}
+	target.DrawTriangles(pkgPolygonVertices, pkgPolygonVertIndices, pkgMask1x1, &pkgFillTrianglesOpts)
+}
+
+// Fills the ring between the given radiuses, approximating
+// the circles with regular polygons.
+func FillRing(target *ebiten.Image, centerX, centerY, innerRadius, outerRadius float64, fillColor color.Color) {
+	const segments = 96
+	if outerRadius <= innerRadius {
+		return
+	}
+
+	r, g, b, a := toRGBAf32(fillColor)
+	pkgPolygonVertices = pkgPolygonVertices[:0]
+	for i := range segments {
+		sin, cos := math.Sincos(2.0 * math.Pi * float64(i) / segments)
+		for _, radius := range [2]float64{innerRadius, outerRadius} {
+			pkgPolygonVertices = append(pkgPolygonVertices, ebiten.Vertex{
+				DstX: float32(centerX + cos*radius), DstY: float32(centerY + sin*radius),
+				SrcX: 0.5, SrcY: 0.5,
+				ColorR: r, ColorG: g, ColorB: b, ColorA: a,
+			})
+		}
+	}
+	pkgPolygonVertIndices = pkgPolygonVertIndices[:0]
+	for i := range segments {
+		inner, outer := uint16(i*2), uint16(i*2+1)
+		nextInner, nextOuter := uint16(((i+1)%segments)*2), uint16(((i+1)%segments)*2+1)
+		pkgPolygonVertIndices = append(pkgPolygonVertIndices, inner, outer, nextOuter, inner, nextOuter, nextInner)
+	}
+	target.DrawTriangles(pkgPolygonVertices, pkgPolygonVertIndices, pkgMask1x1, &pkgFillTrianglesOpts)
+}
+
 func BestFitFloat(dynamicScale bool, layoutWidth, layoutHeight int, renderWidth float64, renderHeight, contextWidth, contextHeight *float64, allowBelowOne bool) float64 {
 	// calculate scale x
 	sx := float64(layoutWidth) / renderWidth
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var testPartialAlphaColors = []color.Color{
+	color.RGBA{0, 0, 128, 128},
+	color.RGBA{64, 32, 16, 64},
+	color.RGBA{100, 150, 200, 200},
+	color.NRGBA{0, 0, 255, 128},
+	color.NRGBA{255, 128, 64, 64},
+	color.NRGBA{100, 150, 200, 200},
+}
+
+var testBackgrounds = []color.RGBA{
+	{0, 0, 0, 0},
+	{255, 255, 255, 255},
+	{40, 80, 120, 255},
+}
+
+func TestFillOverPartialAlpha(t *testing.T) {
+	target := ebiten.NewImage(4, 4)
+	defer target.Deallocate()
+	for _, background := range testBackgrounds {
+		for _, clr := range testPartialAlphaColors {
+			target.Fill(background)
+			FillOver(target, clr)
+			expectPixel(t, target, 2, 2, sourceOver(background, clr), clr)
+		}
+	}
+}
+
+func TestFillOverRectPartialAlpha(t *testing.T) {
+	target := ebiten.NewImage(8, 8)
+	defer target.Deallocate()
+	for _, background := range testBackgrounds {
+		for _, clr := range testPartialAlphaColors {
+			target.Fill(background)
+			FillOverRect(target, image.Rect(2, 2, 6, 6), clr)
+			expectPixel(t, target, 3, 3, sourceOver(background, clr), clr)
+			expectPixel(t, target, 0, 0, background, clr) // outside the rect
+		}
+	}
+}
+
+func TestFillPolygonPartialAlpha(t *testing.T) {
+	target := ebiten.NewImage(8, 8)
+	defer target.Deallocate()
+	square := []image.Point{{0, 0}, {8, 0}, {8, 8}, {0, 8}}
+	for _, background := range testBackgrounds {
+		for _, clr := range testPartialAlphaColors {
+			target.Fill(background)
+			FillPolygon(target, square, clr)
+			expectPixel(t, target, 4, 4, sourceOver(background, clr), clr)
+		}
+	}
+}
+
+// Returns the premultiplied result of drawing clr over background
+// with source-over blending.
+func sourceOver(background color.RGBA, clr color.Color) color.RGBA {
+	r, g, b, a := clr.RGBA() // premultiplied, 16 bits
+	blend := func(src uint32, dst uint8) uint8 {
+		value := float64(src)/257.0 + float64(dst)*(1.0-float64(a)/65535.0)
+		return uint8(min(value+0.5, 255.0))
+	}
+	return color.RGBA{
+		blend(r, background.R),
+		blend(g, background.G),
+		blend(b, background.B),
+		blend(a, background.A),
+	}
+}
+
+func expectPixel(t *testing.T, target *ebiten.Image, x, y int, expected color.RGBA, fill color.Color) {
+	t.Helper()
+	const Tolerance = 2
+	got := target.At(x, y).(color.RGBA)
+	diff := func(a, b uint8) int { return max(int(a)-int(b), int(b)-int(a)) }
+	if diff(got.R, expected.R) > Tolerance || diff(got.G, expected.G) > Tolerance ||
+		diff(got.B, expected.B) > Tolerance || diff(got.A, expected.A) > Tolerance {
+		t.Fatalf("filling with %#v: expected %v at (%d, %d), got %v", fill, expected, x, y, got)
+	}
+}
@@ -10,6 +10,10 @@ type TicksDuration = internal.TicksDuration
 
 const ZeroTicks TicksDuration = 0
 
+// Duration used for shakes that last until explicitly ended.
+// See [ShakeState].
+const IndefiniteTicks TicksDuration = maxUint32
+
 // Quick alias to the control key for use with [AccessorDebug.Printfk]().
 const Ctrl = ebiten.KeyControl
 
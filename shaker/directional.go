@@ -0,0 +1,127 @@
+package shaker
+
+import (
+	"math"
+
+	"github.com/edwinsyarief/mipix/internal"
+)
+
+var _ Shaker = (*Directional)(nil)
+
+// A [Shaker] that oscillates along a specific direction with a
+// decaying sinusoid, with almost no perpendicular motion. Useful
+// for directional impacts, like taking a hit from the left and
+// kicking the screen to the right. The oscillation starts moving
+// towards the configured direction.
+//
+// Since the oscillation decays on its own, this shaker works best
+// with short triggered shakes on a dedicated channel.
+//
+// The implementation is tick-rate independent.
+type Directional struct {
+	dirX, dirY float64
+
+	elapsed         float64
+	magnitude       float64
+	frequency       float64
+	decay           float64
+	zoomCompensated bool
+	initialized     bool
+}
+
+func (self *Directional) ensureInitialized() {
+	if self.initialized {
+		return
+	}
+	self.initialized = true
+	if self.dirX == 0.0 && self.dirY == 0.0 {
+		self.dirX = 1.0
+	}
+	if self.magnitude == 0.0 {
+		self.magnitude = 0.03
+	}
+	if self.frequency == 0.0 {
+		self.frequency = 8.0
+	}
+	if self.decay == 0.0 {
+		self.decay = 4.0
+	}
+}
+
+// Sets the direction of the oscillation, in radians. Zero points
+// right, and positive angles rotate clockwise (y grows downwards).
+// Defaults to 0.
+func (self *Directional) SetDirection(angleRadians float64) {
+	self.ensureInitialized()
+	self.dirY, self.dirX = math.Sincos(angleRadians)
+}
+
+// Sets the maximum offset along the direction, as a fraction of
+// the smallest dimension of the logical resolution. Defaults to 0.03.
+func (self *Directional) SetMagnitude(fraction float64) {
+	if fraction <= 0.0 {
+		panic("magnitude must be strictly positive")
+	}
+	self.ensureInitialized()
+	self.magnitude = fraction
+}
+
+// Sets the oscillation frequency, in hertz. Defaults to 8.0.
+func (self *Directional) SetFrequency(hz float64) {
+	if hz <= 0.0 {
+		panic("frequency must be strictly positive")
+	}
+	self.ensureInitialized()
+	self.frequency = hz
+}
+
+// Sets the exponential decay rate of the oscillation, per second.
+// Higher values make the oscillation die out faster, while zero
+// makes it undamped. Defaults to 4.0.
+func (self *Directional) SetDecay(perSecond float64) {
+	if perSecond < 0.0 {
+		panic("decay can't be negative")
+	}
+	self.ensureInitialized()
+	self.decay = perSecond
+}
+
+// The range of motion of most shakers is based on the logical
+// resolution of the game. This means that when zooming in or
+// out, the shaking effect will become more or less pronounced,
+// respectively. If you want the shaking to maintain the same
+// relative magnitude regardless of zoom level, set zoom
+// compensated to true.
+func (self *Directional) SetZoomCompensated(compensated bool) {
+	self.zoomCompensated = compensated
+}
+
+// Implements the [Shaker] interface.
+func (self *Directional) GetShakeOffsets(level float64) (float64, float64) {
+	const PerpendicularRatio = 0.05
+
+	self.ensureInitialized()
+	if level == 0.0 {
+		self.elapsed = 0.0
+		return 0.0, 0.0
+	}
+
+	envelope := math.Exp(-self.decay * self.elapsed)
+	along := math.Sin(2.0*math.Pi*self.frequency*self.elapsed) * envelope
+	across := math.Sin(2.0*math.Pi*self.frequency*1.7*self.elapsed) * envelope * PerpendicularRatio
+	self.elapsed += 1.0 / float64(internal.GetUPS())
+
+	w, h := internal.GetResolution()
+	axisRange := float64(min(w, h)) * self.magnitude
+	x := (self.dirX*along - self.dirY*across) * axisRange
+	y := (self.dirY*along + self.dirX*across) * axisRange
+	if self.zoomCompensated {
+		currentZoom := internal.GetCurrentZoom()
+		x /= currentZoom
+		y /= currentZoom
+	}
+	if level == 1.0 {
+		return x, y
+	}
+	return internal.CubicSmoothstepInterp(0, x, level), internal.CubicSmoothstepInterp(0, y, level)
+}
@@ -34,6 +34,17 @@ type Shaker interface {
 	GetShakeOffsets(level float64) (float64, float64)
 }
 
+// Optional interface for shakers that also rotate the camera.
+// The rotation is given in radians, and it's applied around the
+// center of the viewport during the final projection.
+//
+// GetShakeRotation() is called right after GetShakeOffsets() with
+// the same level, including the termination call with level = 0,
+// whose results will also be disregarded.
+type RotationalShaker interface {
+	GetShakeRotation(level float64) float64
+}
+
 // Used by ebipixel in case multiple shakes need to be active at the same time.
 //
 // Channel zero is special and will use a fallback shaker even if uninitialized
@@ -0,0 +1,115 @@
+package shaker
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/edwinsyarief/mipix/internal"
+)
+
+var _ Shaker = (*Perlin)(nil)
+
+// A [Shaker] based on 1D Perlin noise, sampled independently for
+// each axis. Unlike [Random], the offsets change continuously and
+// organically, which makes this shaker a good fit for low frequency
+// rumbles, like a big creature landing nearby or a ship's engine.
+//
+// The implementation is tick-rate independent.
+type Perlin struct {
+	gradientsX [256]float64
+	gradientsY [256]float64
+
+	phase           float64
+	frequency       float64
+	amplitude       float64
+	zoomCompensated bool
+	initialized     bool
+}
+
+func (self *Perlin) ensureInitialized() {
+	if self.initialized {
+		return
+	}
+	self.initialized = true
+	if self.frequency == 0.0 {
+		self.frequency = 3.0
+	}
+	if self.amplitude == 0.0 {
+		self.amplitude = 0.02
+	}
+	for i := range self.gradientsX {
+		self.gradientsX[i] = rand.Float64()*2.0 - 1.0
+		self.gradientsY[i] = rand.Float64()*2.0 - 1.0
+	}
+}
+
+// Sets how many noise oscillations happen per second. Low values
+// result in slow, heavy rumbles, while higher values approach more
+// typical shakes. Defaults to 3.0.
+func (self *Perlin) SetFrequency(hz float64) {
+	if hz <= 0.0 {
+		panic("frequency must be strictly positive")
+	}
+	self.frequency = hz
+}
+
+// Sets the maximum offset, as a fraction of the smallest dimension
+// of the logical resolution. For example, with a resolution of 320x180
+// and an amplitude of 0.05, the shaking will range within [-9, +9] in
+// both axes. Defaults to 0.02.
+func (self *Perlin) SetAmplitude(maxFraction float64) {
+	if maxFraction <= 0.0 {
+		panic("amplitude must be strictly positive")
+	}
+	self.amplitude = maxFraction
+}
+
+// The range of motion of most shakers is based on the logical
+// resolution of the game. This means that when zooming in or
+// out, the shaking effect will become more or less pronounced,
+// respectively. If you want the shaking to maintain the same
+// relative magnitude regardless of zoom level, set zoom
+// compensated to true.
+func (self *Perlin) SetZoomCompensated(compensated bool) {
+	self.zoomCompensated = compensated
+}
+
+// Implements the [Shaker] interface.
+func (self *Perlin) GetShakeOffsets(level float64) (float64, float64) {
+	self.ensureInitialized()
+	if level == 0.0 {
+		self.phase = 0.0
+		return 0.0, 0.0
+	}
+
+	x := perlinNoise1D(&self.gradientsX, self.phase)
+	y := perlinNoise1D(&self.gradientsY, self.phase)
+	self.phase += self.frequency / float64(internal.GetUPS())
+	self.phase = math.Mod(self.phase, float64(len(self.gradientsX)))
+
+	w, h := internal.GetResolution()
+	axisRange := float64(min(w, h)) * self.amplitude
+	x, y = x*axisRange, y*axisRange
+	if self.zoomCompensated {
+		currentZoom := internal.GetCurrentZoom()
+		x /= currentZoom
+		y /= currentZoom
+	}
+	if level == 1.0 {
+		return x, y
+	}
+	return internal.CubicSmoothstepInterp(0, x, level), internal.CubicSmoothstepInterp(0, y, level)
+}
+
+// Returns gradient noise in [-1, 1] range. The noise is zero at
+// integer positions and wraps around the gradient table length.
+func perlinNoise1D(gradients *[256]float64, position float64) float64 {
+	floor := math.Floor(position)
+	index := int(floor)
+	t := position - floor
+	g0 := gradients[index&255]
+	g1 := gradients[(index+1)&255]
+	fade := t * t * t * (t*(t*6.0-15.0) + 10.0)
+	n := internal.LinearInterp(g0*t, g1*(t-1.0), fade)
+	return n * 2.0 // 1D gradient noise peaks at 0.5
+}
@@ -0,0 +1,107 @@
+package shaker
+
+import (
+	"math"
+
+	"github.com/edwinsyarief/mipix/internal"
+)
+
+var _ Shaker = (*Recorded)(nil)
+
+// See [Recorded].
+type LoopMode uint8
+
+const (
+	LoopOnce     LoopMode = iota // hold the last sample after reaching the end
+	LoopRepeat                   // interpolate from the last sample back to the first
+	LoopPingPong                 // play backwards after the end, then forwards again
+)
+
+// A [Shaker] that plays back sampled offsets, typically hand-authored
+// in external tools. This allows designers to craft exact shake motions
+// instead of relying on parametric noise.
+//
+// To preserve resolution independence, offsets are given relative
+// to the game's logical resolution: x offsets are multiplied by the
+// logical width and y offsets by the logical height. For example,
+// with a resolution of 320x180, an x offset of 0.01 becomes 3.2
+// logical pixels.
+//
+// Samples are linearly interpolated. If the slices have different
+// lengths, each axis is played back independently. With [LoopRepeat],
+// the last sample is also interpolated into the first one, so the
+// samples shouldn't repeat the starting value at the end.
+//
+// The implementation is tick-rate independent.
+type Recorded struct {
+	OffsetsX []float64
+	OffsetsY []float64
+
+	// Samples per second. If zero, 60 is used.
+	SampleRate float64
+
+	// Playback behavior after reaching the end of the samples.
+	Loop LoopMode
+
+	elapsed float64
+}
+
+// Implements the [Shaker] interface.
+func (self *Recorded) GetShakeOffsets(level float64) (float64, float64) {
+	if level == 0.0 {
+		self.elapsed = 0.0
+		return 0.0, 0.0
+	}
+
+	sampleRate := self.SampleRate
+	if sampleRate <= 0.0 {
+		sampleRate = 60.0
+	}
+	position := self.elapsed * sampleRate
+	self.elapsed += 1.0 / float64(internal.GetUPS())
+
+	w, h := internal.GetResolution()
+	x := self.sampleAt(self.OffsetsX, position) * float64(w)
+	y := self.sampleAt(self.OffsetsY, position) * float64(h)
+	if level == 1.0 {
+		return x, y
+	}
+	return internal.CubicSmoothstepInterp(0, x, level), internal.CubicSmoothstepInterp(0, y, level)
+}
+
+func (self *Recorded) sampleAt(samples []float64, position float64) float64 {
+	switch len(samples) {
+	case 0:
+		return 0.0
+	case 1:
+		return samples[0]
+	}
+
+	last := float64(len(samples) - 1)
+	switch self.Loop {
+	case LoopOnce:
+		position = min(position, last)
+	case LoopRepeat:
+		// the last sample is interpolated back into the first one,
+		// so each loop lasts len(samples) samples
+		position = math.Mod(position, float64(len(samples)))
+		index := int(position)
+		next := (index + 1) % len(samples)
+		t := position - float64(index)
+		return internal.LinearInterp(samples[index], samples[next], t)
+	case LoopPingPong:
+		position = math.Mod(position, 2.0*last)
+		if position > last {
+			position = 2.0*last - position
+		}
+	default:
+		panic("invalid LoopMode")
+	}
+
+	index := int(position)
+	if index >= len(samples)-1 {
+		return samples[len(samples)-1]
+	}
+	t := position - float64(index)
+	return internal.LinearInterp(samples[index], samples[index+1], t)
+}
@@ -0,0 +1,40 @@
+package tracker
+
+import "github.com/edwinsyarief/mipix/internal"
+
+var _ Tracker = (*Blend)(nil)
+var _ Seeder = (*Blend)(nil)
+
+// A [Tracker] that combines the outputs of two trackers through a
+// linear interpolation. A Weight of 0 uses only A's output, while a
+// Weight of 1 uses only B's output.
+//
+// Animating the Weight from 0 to 1 allows smoothly handing off from
+// one tracking behavior to another (e.g., from [Instant] to a [Spring])
+// instead of swapping trackers abruptly.
+//
+// Both trackers are updated every tick even if their weight is zero,
+// so stateful trackers remain coherent through the transition.
+type Blend struct {
+	A, B   Tracker
+	Weight float64 // expected to be in [0, 1]
+}
+
+// Implements [Tracker].
+func (self *Blend) Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY float64) (float64, float64) {
+	ax, ay := self.A.Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY)
+	bx, by := self.B.Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY)
+	t := min(max(self.Weight, 0.0), 1.0)
+	return internal.LinearInterp(ax, bx, t), internal.LinearInterp(ay, by, t)
+}
+
+// Implements [Seeder]. The seed is forwarded to A and
+// B if they implement the interface themselves.
+func (self *Blend) Seed(vx, vy float64) {
+	if seeder, ok := self.A.(Seeder); ok {
+		seeder.Seed(vx, vy)
+	}
+	if seeder, ok := self.B.(Seeder); ok {
+		seeder.Seed(vx, vy)
+	}
+}
@@ -0,0 +1,74 @@
+package tracker
+
+import "github.com/edwinsyarief/mipix/internal"
+
+var _ Tracker = (*Delayed)(nil)
+var _ Seeder = (*Delayed)(nil)
+
+// Alias for mipix.TicksDuration.
+type TicksDuration = internal.TicksDuration
+
+// A [Tracker] decorator that feeds the Inner tracker with the target
+// coordinates from Delay ticks ago, instead of the current ones. This
+// makes the camera trail behind its target with a fixed latency, which
+// creates a "heavy camera" feel that's quite different from smoothing.
+//
+// The delay is expressed in ticks, so it's tick-rate independent. During
+// the first updates, before enough history has been buffered, the oldest
+// known target is used. Changing the Delay on the fly is allowed.
+type Delayed struct {
+	Inner Tracker
+	Delay TicksDuration
+
+	ring  []delayedTarget
+	head  int // index of the oldest sample
+	count int
+	tick  uint64
+}
+
+type delayedTarget struct {
+	tick uint64
+	x, y float64
+}
+
+// Implements [Tracker].
+func (self *Delayed) Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY float64) (float64, float64) {
+	self.tick += max(internal.GetTPU(), 1)
+	self.push(delayedTarget{self.tick, targetX, targetY})
+
+	// discard samples that are too old, keeping the
+	// newest one that's at least Delay ticks behind
+	delayedTick := self.tick - min(uint64(self.Delay), self.tick)
+	for self.count > 1 && self.at(1).tick <= delayedTick {
+		self.head = (self.head + 1) % len(self.ring)
+		self.count -= 1
+	}
+
+	delayed := self.at(0)
+	return self.Inner.Update(currentX, currentY, delayed.x, delayed.y, prevSpeedX, prevSpeedY)
+}
+
+// Implements [Seeder]. The seed is forwarded to the
+// Inner tracker if it implements the interface itself.
+func (self *Delayed) Seed(vx, vy float64) {
+	if seeder, ok := self.Inner.(Seeder); ok {
+		seeder.Seed(vx, vy)
+	}
+}
+
+func (self *Delayed) at(index int) delayedTarget {
+	return self.ring[(self.head+index)%len(self.ring)]
+}
+
+func (self *Delayed) push(sample delayedTarget) {
+	if self.count == len(self.ring) { // grow ring
+		ring := make([]delayedTarget, max(len(self.ring)*2, 16))
+		for i := range self.count {
+			ring[i] = self.at(i)
+		}
+		self.ring = ring
+		self.head = 0
+	}
+	self.ring[(self.head+self.count)%len(self.ring)] = sample
+	self.count += 1
+}
@@ -24,3 +24,11 @@ package tracker
 type Tracker interface {
 	Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY float64) (float64, float64)
 }
+
+// Optional interface for trackers that keep an internal velocity
+// and can be seeded with an initial one. The camera uses this on
+// mipix.AccessorCamera.SetTrackerSmooth() to make tracker handoffs
+// continuous. Velocities are given in logical units per second.
+type Seeder interface {
+	Seed(vx, vy float64)
+}
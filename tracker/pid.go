@@ -0,0 +1,135 @@
+package tracker
+
+import (
+	"math"
+
+	ebimath "github.com/edwinsyarief/ebi-math"
+	"github.com/edwinsyarief/mipix/internal"
+)
+
+var _ Tracker = (*PID)(nil)
+
+// A tracker based on a classic PID controller. The distance between
+// the target and the current position is used as the error signal,
+// and the controller output is used as the camera speed:
+//   - The proportional gain makes the camera move faster the further
+//     away it is from the target.
+//   - The integral gain accumulates the error over time, which helps
+//     reaching targets that keep moving at a steady speed.
+//   - The derivative gain reacts to changes in the error, damping
+//     the motion and reducing overshoot.
+//
+// Errors are measured in screens (logical resolution adjusted by
+// the current zoom level) and times in seconds, so the same gains
+// feel the same regardless of resolution and zoom.
+//
+// The accumulated integral is clamped to prevent windup. If you
+// teleport the camera or change scenes, call [PID.Reset]() to clear
+// the accumulated state.
+//
+// The implementation is tick-rate independent.
+type PID struct {
+	kp, ki, kd    float64
+	integralLimit float64
+
+	integralX, integralY   float64
+	prevErrorX, prevErrorY float64
+	hasPrevError           bool
+	initialized            bool
+}
+
+func (self *PID) initialize() {
+	self.initialized = true
+	if self.kp == 0.0 && self.ki == 0.0 && self.kd == 0.0 {
+		self.kp, self.ki, self.kd = 5.0, 0.5, 0.1
+	}
+	if self.integralLimit == 0.0 {
+		self.integralLimit = 0.5
+	}
+}
+
+// Sets the proportional, integral and derivative gains of the
+// controller. Gains can't be negative. The defaults are 5.0, 0.5
+// and 0.1, respectively.
+//
+// As a rule of thumb, start with only kp, increase kd if the camera
+// overshoots, and only add ki if the camera lags behind targets
+// moving at a constant speed.
+func (self *PID) SetGains(kp, ki, kd float64) {
+	if kp < 0.0 || ki < 0.0 || kd < 0.0 {
+		panic("PID gains can't be negative")
+	}
+	if kp == 0.0 && ki == 0.0 && kd == 0.0 {
+		panic("at least one PID gain must be non-zero")
+	}
+	if !self.initialized {
+		self.initialize()
+	}
+	self.kp, self.ki, self.kd = kp, ki, kd
+}
+
+// Sets the maximum absolute value of the accumulated integral on
+// each axis, in screens * seconds. Lower values reduce overshoot
+// after the camera has been lagging behind for a while. Defaults
+// to 0.5.
+func (self *PID) SetIntegralLimit(screenSeconds float64) {
+	if screenSeconds <= 0.0 {
+		panic("integral limit must be strictly positive")
+	}
+	if !self.initialized {
+		self.initialize()
+	}
+	self.integralLimit = screenSeconds
+}
+
+// Clears the accumulated integral and the previous error.
+// Typically used on scene changes or after teleporting the
+// camera, to prevent the old state from affecting the new
+// tracking.
+func (self *PID) Reset() {
+	self.integralX, self.integralY = 0.0, 0.0
+	self.prevErrorX, self.prevErrorY = 0.0, 0.0
+	self.hasPrevError = false
+}
+
+// Implements [Tracker].
+func (self *PID) Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY float64) (float64, float64) {
+	if !self.initialized {
+		self.initialize()
+	}
+
+	// stabilization case
+	if ebimath.Abs(targetX-currentX) < 0.001 && ebimath.Abs(targetY-currentY) < 0.001 {
+		self.Reset()
+		return targetX - currentX, targetY - currentY
+	}
+
+	w, h := internal.GetResolution()
+	zoom := internal.GetCurrentZoom()
+	zoomedWidth, zoomedHeight := float64(w)/zoom, float64(h)/zoom
+
+	updateDelta := 1.0 / float64(internal.GetUPS())
+	errorX := (targetX - currentX) / zoomedWidth
+	errorY := (targetY - currentY) / zoomedHeight
+	speedX := self.updateComponent(errorX, &self.integralX, self.prevErrorX, updateDelta)
+	speedY := self.updateComponent(errorY, &self.integralY, self.prevErrorY, updateDelta)
+	self.prevErrorX, self.prevErrorY = errorX, errorY
+	self.hasPrevError = true
+
+	changeX := speedX * zoomedWidth * updateDelta
+	changeY := speedY * zoomedHeight * updateDelta
+	if math.IsNaN(changeX) || math.IsNaN(changeY) || math.IsInf(changeX, 0) || math.IsInf(changeY, 0) {
+		self.Reset()
+		return targetX - currentX, targetY - currentY
+	}
+	return changeX, changeY
+}
+
+func (self *PID) updateComponent(err float64, integral *float64, prevErr, updateDelta float64) float64 {
+	*integral = ebimath.Clamp(*integral+err*updateDelta, -self.integralLimit, self.integralLimit)
+	var derivative float64
+	if self.hasPrevError {
+		derivative = (err - prevErr) / updateDelta
+	}
+	return self.kp*err + self.ki*(*integral) + self.kd*derivative
+}
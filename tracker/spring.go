@@ -5,6 +5,8 @@ import (
 	"github.com/edwinsyarief/mipix/internal"
 )
 
+var _ Seeder = (*Spring)(nil)
+
 type Spring struct {
 	spring         internal.Spring
 	speedX, speedY float64
@@ -23,6 +25,19 @@ func (self *Spring) SetParameters(damping, power float64) {
 	self.initialized = true
 }
 
+// Implements [Seeder].
+func (self *Spring) Seed(vx, vy float64) {
+	// internal speeds are relative to the resolution,
+	// and changes are scaled by zoom on each update
+	w, h := internal.GetResolution()
+	zoom := internal.GetCurrentZoom()
+	if w == 0 || h == 0 || zoom == 0 {
+		return
+	}
+	self.speedX = vx / (float64(w) * zoom)
+	self.speedY = vy / (float64(h) * zoom)
+}
+
 func (self *Spring) Update(currentX, currentY, targetX, targetY, prevSpeedX, prevSpeedY float64) (float64, float64) {
 	// initialization
 	if !self.initialized {
@@ -0,0 +1,62 @@
+// This package exposes the interpolation and easing functions used
+// internally by mipix trackers, zoomers and shakers, and provides
+// a simple [Tween] type to animate values over a [TicksDuration].
+//
+// Easing functions take a t value in [0, 1] range (values outside
+// the range are clamped) and return the eased t. Interpolation
+// functions take the start and end values in addition to t.
+package tween
+
+import "github.com/edwinsyarief/mipix/internal"
+
+// Returns the relative position of x within [a, b], clamped
+// to [0, 1]. If a > b, the range is considered reversed.
+// This is the inverse of [LinearInterp]().
+func TAt(x, a, b float64) float64 {
+	return internal.TAt(x, a, b)
+}
+
+// Linear interpolation between a and b. Not clamped.
+func LinearInterp(a, b, t float64) float64 {
+	return internal.LinearInterp(a, b, t)
+}
+
+// Cubic smoothstep easing, also known as "smoothstep".
+func CubicSmoothstep(t float64) float64 {
+	return internal.CubicSmoothstepInterp(0, 1, t)
+}
+
+// Interpolation between a and b using [CubicSmoothstep]().
+func CubicSmoothstepInterp(a, b, t float64) float64 {
+	return internal.CubicSmoothstepInterp(a, b, t)
+}
+
+// Quadratic ease in and out.
+func QuadInOut(t float64) float64 {
+	return internal.QuadInOut(t)
+}
+
+// Interpolation between a and b using [QuadInOut]().
+func QuadInOutInterp(a, b, t float64) float64 {
+	return internal.QuadInOutInterp(a, b, t)
+}
+
+// Quadratic ease in.
+func EaseInQuad(t float64) float64 {
+	return internal.EaseInQuad(t)
+}
+
+// Quadratic ease out.
+func EaseOutQuad(t float64) float64 {
+	return internal.EaseOutQuad(t)
+}
+
+// Cubic ease out.
+func EaseOutCubic(t float64) float64 {
+	return internal.EaseOutCubic(t)
+}
+
+// Interpolation between a and b using [EaseOutCubic]().
+func CubicOutInterp(a, b, t float64) float64 {
+	return internal.CubicOutInterp(a, b, t)
+}
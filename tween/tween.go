@@ -0,0 +1,62 @@
+package tween
+
+import "github.com/edwinsyarief/mipix/internal"
+
+// Alias for mipix.TicksDuration.
+type TicksDuration = internal.TicksDuration
+
+// A simple helper to animate a value from one point to another
+// over a [TicksDuration]. Call [Tween.Update]() once per game
+// update, and the tween will advance by the current tick rate.
+//
+// Example usage:
+//
+//	fade := tween.Tween{From: 0, To: 1, Duration: 60, Easing: tween.QuadInOut}
+//	// ...on each update:
+//	alpha := fade.Update()
+type Tween struct {
+	From     float64
+	To       float64
+	Duration TicksDuration
+
+	// If nil, linear easing is used.
+	Easing func(t float64) float64
+
+	elapsed TicksDuration
+}
+
+// Advances the tween by one update and returns the new value.
+func (self *Tween) Update() float64 {
+	if self.elapsed < self.Duration {
+		self.elapsed += TicksDuration(max(internal.GetTPU(), 1))
+		self.elapsed = min(self.elapsed, self.Duration)
+	}
+	return self.Value()
+}
+
+// Returns the current value without advancing the tween.
+func (self *Tween) Value() float64 {
+	t := self.Progress()
+	if self.Easing != nil {
+		t = self.Easing(t)
+	}
+	return internal.LinearInterp(self.From, self.To, t)
+}
+
+// Returns the tween progress, in [0, 1] range.
+func (self *Tween) Progress() float64 {
+	if self.Duration == 0 {
+		return 1.0
+	}
+	return float64(self.elapsed) / float64(self.Duration)
+}
+
+// Returns whether the tween has reached its end.
+func (self *Tween) Done() bool {
+	return self.elapsed >= self.Duration
+}
+
+// Restarts the tween from the beginning.
+func (self *Tween) Reset() {
+	self.elapsed = 0
+}
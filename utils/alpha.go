@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Reads the pixels of the given image and returns them as straight
+// (non-premultiplied) alpha, which is what most image editors and
+// file formats expect. Fully transparent pixels have indeterminate
+// color channels in premultiplied alpha, so they are returned as
+// transparent black.
+//
+// Like [ebiten.Image.ReadPixels](), this can't be called before
+// the game starts running.
+func Unpremultiply(src *ebiten.Image) *image.NRGBA {
+	bounds := src.Bounds()
+	out := image.NewNRGBA(bounds)
+	src.ReadPixels(out.Pix)
+	for i := 0; i < len(out.Pix); i += 4 {
+		a := uint32(out.Pix[i+3])
+		switch a {
+		case 0:
+			out.Pix[i+0], out.Pix[i+1], out.Pix[i+2] = 0, 0, 0
+		case 255:
+			// nothing to do
+		default:
+			out.Pix[i+0] = uint8((uint32(out.Pix[i+0])*255 + a/2) / a)
+			out.Pix[i+1] = uint8((uint32(out.Pix[i+1])*255 + a/2) / a)
+			out.Pix[i+2] = uint8((uint32(out.Pix[i+2])*255 + a/2) / a)
+		}
+	}
+	return out
+}
+
+// Creates a new [ebiten.Image] from the given image, converting
+// its colors to premultiplied alpha. Pixels are interpreted through
+// [color.NRGBAModel], so [*image.NRGBA] sources (the common case for
+// assets authored in straight alpha) are read without any loss.
+// Fully transparent pixels always become transparent black, no
+// matter what their color channels contained.
+//
+// The returned image has its bounds starting at (0, 0).
+func Premultiply(src image.Image) *ebiten.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pixels := make([]byte, 4*width*height)
+	var i int
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			clr := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+			a := uint32(clr.A)
+			pixels[i+0] = uint8((uint32(clr.R)*a + 127) / 255)
+			pixels[i+1] = uint8((uint32(clr.G)*a + 127) / 255)
+			pixels[i+2] = uint8((uint32(clr.B)*a + 127) / 255)
+			pixels[i+3] = clr.A
+			i += 4
+		}
+	}
+
+	img := ebiten.NewImage(width, height)
+	img.WritePixels(pixels)
+	return img
+}
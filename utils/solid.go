@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"image/color"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var pkgSolidImages map[color.RGBA64]*ebiten.Image
+var pkgSolidImagesMutex sync.Mutex
+
+// Returns a 1x1 image filled with the given color. Images are cached
+// by color, so repeated calls with the same color return the same image.
+// Mostly useful for [ebiten.Image.DrawImage]()-based fills, where you
+// scale the image to the desired area through the GeoM.
+//
+// The returned image is shared, so it must never be modified or
+// deallocated. Caching is unbounded, so avoid calling this with
+// many different colors, like on color animations.
+func SolidImage(clr color.Color) *ebiten.Image {
+	r, g, b, a := clr.RGBA()
+	key := color.RGBA64{uint16(r), uint16(g), uint16(b), uint16(a)}
+
+	pkgSolidImagesMutex.Lock()
+	defer pkgSolidImagesMutex.Unlock()
+	img, found := pkgSolidImages[key]
+	if !found {
+		if pkgSolidImages == nil {
+			pkgSolidImages = make(map[color.RGBA64]*ebiten.Image)
+		}
+		img = ebiten.NewImage(1, 1)
+		img.Fill(key)
+		pkgSolidImages[key] = img
+	}
+	return img
+}
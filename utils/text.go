@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Size of the glyph cells of the built-in debug bitmap font.
+const (
+	DebugFontGlyphWidth  = 6
+	DebugFontGlyphHeight = 16
+)
+
+// Draws the given text with the built-in debug bitmap font, the same
+// used by mipix.Debug().Drawf(). The text is always white, and each
+// character takes a [DebugFontGlyphWidth] x [DebugFontGlyphHeight]
+// cell. Multi-line text is supported.
+//
+// This is not a replacement for a proper text library, but it's
+// enough for scores, labels and other simple text in small games.
+func DrawText(target *ebiten.Image, text string, x, y int) {
+	ebitenutil.DebugPrintAt(target, text, x, y)
+}
+
+// Returns the size that the given text will take when drawn with
+// [DrawText]().
+func MeasureText(text string) (width, height int) {
+	if text == "" {
+		return 0, 0
+	}
+
+	var maxChars int
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		maxChars = max(maxChars, utf8.RuneCountInString(line))
+	}
+	return maxChars * DebugFontGlyphWidth, len(lines) * DebugFontGlyphHeight
+}
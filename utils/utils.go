@@ -3,6 +3,7 @@ package utils
 import (
 	"image"
 	"image/color"
+	"math"
 
 	"github.com/edwinsyarief/mipix/internal"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -20,6 +21,10 @@ func SubImage(source *ebiten.Image, minX, minY, maxX, maxY int) *ebiten.Image {
 
 // Similar to [ebiten.Image.Fill](), but with alpha blending.
 // See also [FillOverRect]().
+//
+// Colors are converted through [color.Color.RGBA](), so
+// [color.RGBA] values must be premultiplied, but straight-alpha
+// colors can be passed as [color.NRGBA] and will blend correctly.
 func FillOver(target *ebiten.Image, fillColor color.Color) {
 	internal.FillOver(target, fillColor)
 }
@@ -76,6 +81,59 @@ func MaskToImageWithOrigin(ox, oy int, width int, mask []uint8, colors ...color.
 	return ebiten.NewImageFromImageWithOptions(rgba, &opts)
 }
 
+// Similar to [MaskToImage](), but mask values are interpreted as
+// coverage instead of color indices: 0 is fully transparent, 255 is
+// fully covered, and intermediate values scale the color's alpha
+// proportionally. This allows anti-aliased edges on hand-drawn icons.
+//
+// With a single color, coverage only scales that color. With multiple
+// colors, they are treated as a gradient from the first (at minimum
+// coverage) to the last (at full coverage), which can be used to tint
+// anti-aliased edges. As with the rest of the package, colors are
+// expected to be premultiplied; see [RGBA]().
+func MaskToImageAA(width int, mask []uint8, colors ...color.RGBA) *ebiten.Image {
+	// safety assertions
+	if width <= 0 {
+		panic("expected width > 0")
+	}
+	height := len(mask) / width
+	if height*width != len(mask) {
+		panic("given width can't split given mask into rows of equal length")
+	}
+
+	// no colors fallback
+	if len(colors) == 0 {
+		colors = []color.RGBA{{255, 255, 255, 255}}
+	}
+
+	// create image
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	for index, value := range mask {
+		if value == 0 {
+			continue
+		}
+		coverage := float64(value) / 255.0
+		clr := colors[0]
+		if len(colors) > 1 {
+			position := coverage * float64(len(colors)-1)
+			i := min(int(position), len(colors)-2)
+			clr = lerpRGBA(colors[i], colors[i+1], position-float64(i))
+		}
+
+		pixelIndex := index << 2
+		rgba.Pix[pixelIndex+0] = uint8(float64(clr.R)*coverage + 0.5)
+		rgba.Pix[pixelIndex+1] = uint8(float64(clr.G)*coverage + 0.5)
+		rgba.Pix[pixelIndex+2] = uint8(float64(clr.B)*coverage + 0.5)
+		rgba.Pix[pixelIndex+3] = uint8(float64(clr.A)*coverage + 0.5)
+	}
+	return ebiten.NewImageFromImage(rgba)
+}
+
+func lerpRGBA(a, b color.RGBA, t float64) color.RGBA {
+	lerp := func(x, y uint8) uint8 { return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5) }
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), lerp(a.A, b.A)}
+}
+
 // Returns the given bounds translated by the given (x, y) values.
 // Equivalent to bounds.Add(image.Pt(x, y)).
 func Shift(bounds image.Rectangle, x, y int) image.Rectangle {
@@ -95,6 +153,27 @@ func GeoMAt(source *ebiten.Image, x, y int) ebiten.GeoM {
 	return geom
 }
 
+// Like [GeoMAt](), but as if the camera origin was scaled by
+// the given parallax factor. A factor of 0 keeps the image fixed
+// to the screen, 1 gives full world motion, and values in between
+// make the image scroll slower, like distant background layers.
+// Factors above 1 can be used for foreground layers.
+//
+// Fractional camera offsets are truncated towards negative infinity,
+// so the result stays aligned to the logical pixel grid.
+func GeoMAtParallax(source *ebiten.Image, x, y int, factor float64) ebiten.GeoM {
+	var geom ebiten.GeoM
+	origin := internal.BridgedCameraOrigin
+	originX := int(math.Floor(float64(origin.X) * factor))
+	originY := int(math.Floor(float64(origin.Y) * factor))
+	localXY := image.Pt(x-originX, y-originY)
+	localXY = localXY.Add(source.Bounds().Min) // *
+	// * origin is not automatically applied when using
+	//   an image as source, so we need to add it manually
+	geom.Translate(float64(localXY.X), float64(localXY.Y))
+	return geom
+}
+
 // Returns the image options with a GeoM set up to draw the
 // given image at the logical global coordinates (x, y).
 // Makes basic image drawing simpler. Example code:
@@ -111,12 +190,31 @@ func DrawImageOptionsAt(source *ebiten.Image, x, y int) ebiten.DrawImageOptions
 	return opts
 }
 
+// Draws the source image on the logical canvas at the logical
+// global coordinates (x, y), tinted with the given color scale.
+// Shorthand for [DrawImageOptionsAt]() + setting the color scale
+// + drawing. Pass an ebiten.ColorScale{} to draw without tinting.
+func DrawTintedAt(canvas, source *ebiten.Image, x, y int, colorScale ebiten.ColorScale) {
+	opts := DrawImageOptionsAt(source, x, y)
+	opts.ColorScale = colorScale
+	canvas.DrawImage(source, &opts)
+}
+
 // Similar to [ebiten.Image.Fill](), but with alpha blending
 // and explicit target bounds. See also [FillOver]().
 func FillOverRect(target *ebiten.Image, bounds image.Rectangle, fillColor color.Color) {
 	internal.FillOverRect(target, bounds, fillColor)
 }
 
+// Fills the polygon defined by the given points with alpha blending.
+// Like [FillOverRect](), the points are given in target coordinates.
+// The polygon is drawn as a triangle fan from the first point, so
+// only convex polygons are guaranteed to be filled correctly.
+// Polygons with less than 3 points are ignored.
+func FillPolygon(target *ebiten.Image, points []image.Point, clr color.Color) {
+	internal.FillPolygon(target, points, clr)
+}
+
 // (we already have HiRes().FillOverRect() and stuff)
 // func FillOverRectF64(target *ebiten.Image, minX, minY, maxX, maxY float64, fillColor color.Color) {
 // 	internal.FillOverRectF32(target, float32(minX), float32(minY), float32(maxX), float32(maxY), fillColor)
@@ -131,6 +229,20 @@ func Rect(minX, minY, maxX, maxY int) image.Rectangle {
 	return image.Rect(minX, minY, maxX, maxY)
 }
 
+// Maps the given world coordinates from worldBounds to mapRect
+// proportionally. Typically used for minimaps, where mapRect is
+// the minimap area on screen. Coordinates outside worldBounds are
+// not clamped. Empty worldBounds map everything to mapRect.Min.
+func WorldToRect(worldX, worldY float64, worldBounds, mapRect image.Rectangle) (float64, float64) {
+	worldWidth, worldHeight := float64(worldBounds.Dx()), float64(worldBounds.Dy())
+	if worldWidth <= 0 || worldHeight <= 0 {
+		return float64(mapRect.Min.X), float64(mapRect.Min.Y)
+	}
+	relX := (worldX - float64(worldBounds.Min.X)) / worldWidth
+	relY := (worldY - float64(worldBounds.Min.Y)) / worldHeight
+	return float64(mapRect.Min.X) + relX*float64(mapRect.Dx()), float64(mapRect.Min.Y) + relY*float64(mapRect.Dy())
+}
+
 // func RoundCoords(x, y float64) (int, int) {
 // 	return int(math.Round(x)), int(math.Round(y))
 // }
@@ -156,7 +268,9 @@ func RGBA(r, g, b, a uint8) color.RGBA {
 
 // Converts a color to float32 RGBA values in [0, 1] range.
 //
-// This is the format that [ebiten.Vertex] expects.
+// The values are premultiplied, so when using them on [ebiten.Vertex]
+// colors, [ebiten.DrawTrianglesOptions].ColorScaleMode must be set
+// to [ebiten.ColorScaleModePremultipliedAlpha].
 func ColorToF32(clr color.Color) (r, g, b, a float32) {
 	r16, g16, b16, a16 := clr.RGBA()
 	return float32(r16) / 65535.0, float32(g16) / 65535.0, float32(b16) / 65535.0, float32(a16) / 65535.0
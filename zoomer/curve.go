@@ -0,0 +1,100 @@
+package zoomer
+
+import (
+	"math"
+
+	"github.com/edwinsyarief/mipix/internal"
+)
+
+var _ Zoomer = (*Curve)(nil)
+
+// A zoomer that animates from the current zoom level to the target
+// over a fixed duration, following an arbitrary easing curve. Any
+// of the functions in the tween subpackage can be used as the curve,
+// which makes this zoomer convenient for designers to tweak.
+//
+// Interpolation is done in logarithmic zoom space, so going from
+// x1.0 to x2.0 feels as fast as going from x2.0 to x4.0.
+//
+// When the target changes mid-animation, the animation is
+// re-parameterized towards the new target from the current
+// zoom level. If enough progress along the curve remains, the
+// progress is preserved so the motion doesn't stall. Otherwise,
+// a new animation of the full duration is started.
+//
+// The implementation is update-rate independent.
+type Curve struct {
+	// The easing curve, mapping [0, 1] progress to [0, 1] values.
+	// If nil, linear interpolation is used.
+	Curve func(t float64) float64
+
+	// The duration of the animation, in ticks. If zero,
+	// zoom changes are applied instantly.
+	Duration TicksDuration
+
+	logFrom float64
+	logTo   float64
+	target  float64
+	elapsed TicksDuration
+}
+
+// Implements [Zoomer].
+func (self *Curve) Reset() {
+	zoom := internal.GetCurrentZoom()
+	self.logFrom, self.logTo = math.Log(zoom), math.Log(zoom)
+	self.target = zoom
+	self.elapsed = self.Duration
+}
+
+// Implements [Zoomer].
+func (self *Curve) Update(currentZoom, targetZoom float64) float64 {
+	if self.Duration == 0 || currentZoom <= 0.0 || targetZoom <= 0.0 {
+		return targetZoom - currentZoom
+	}
+
+	if targetZoom != self.target {
+		self.retarget(currentZoom, targetZoom)
+	}
+	if self.elapsed >= self.Duration {
+		return targetZoom - currentZoom
+	}
+
+	self.elapsed = min(self.elapsed+TicksDuration(internal.GetTPU()), self.Duration)
+	if self.elapsed == self.Duration {
+		return targetZoom - currentZoom
+	}
+	value := self.valueAt(self.progress())
+	return math.Exp(self.logFrom+(self.logTo-self.logFrom)*value) - currentZoom
+}
+
+func (self *Curve) retarget(currentZoom, targetZoom float64) {
+	const MinRemaining = 0.25
+
+	logCurrent, logTarget := math.Log(currentZoom), math.Log(targetZoom)
+	self.target = targetZoom
+	self.logTo = logTarget
+
+	// preserve progress if possible, solving the start value
+	// so the curve passes through the current zoom level
+	if self.elapsed > 0 && self.elapsed < self.Duration {
+		value := self.valueAt(self.progress())
+		if 1.0-value >= MinRemaining {
+			self.logFrom = (logCurrent - logTarget*value) / (1.0 - value)
+			return
+		}
+	}
+
+	self.logFrom = logCurrent
+	self.elapsed = 0
+}
+
+func (self *Curve) progress() float64 {
+	return float64(self.elapsed) / float64(self.Duration)
+}
+
+func (self *Curve) valueAt(t float64) float64 {
+	if self.Curve == nil {
+		return t
+	}
+	return self.Curve(t)
+}
@@ -20,6 +20,8 @@ type Quadratic struct {
 	speed        float64
 	acceleration float64 // absolute value, always positive, configurable
 	maxSpeed     float64 // absolute value, always positive, configurable
+	inFactorOff  float64 // zoom in speed factor - 1.0
+	outFactorOff float64 // zoom out speed factor - 1.0
 	initialized  bool
 }
 
@@ -52,6 +54,20 @@ func (self *Quadratic) SetMaxSpeed(maxSpeed float64) {
 	self.maxSpeed = maxSpeed
 }
 
+// Zooming in and out at the same speed often feels perceptually
+// different. This method allows setting separate speed factors for
+// zoom ins (target > current) and zoom outs (target < current).
+// Both acceleration and max speed are scaled by the relevant factor.
+//
+// Factors must be strictly positive. The defaults are (1.0, 1.0).
+func (self *Quadratic) SetAsymmetricSpeed(inFactor, outFactor float64) {
+	if inFactor <= 0.0 || outFactor <= 0.0 {
+		panic("zoom speed factors must be strictly positive")
+	}
+	self.inFactorOff = inFactor - 1.0
+	self.outFactorOff = outFactor - 1.0
+}
+
 // Implements [Zoomer].
 func (self *Quadratic) Reset() {
 	self.speed = 0.0
@@ -70,25 +86,33 @@ func (self *Quadratic) Update(currentZoom, targetZoom float64) float64 {
 	// and the integral of this is acceleration*(speed^2/2.0),
 	// which gives us the distance)
 	distance := (targetZoom - currentZoom)
-	predicted := (self.speed * self.speed) / (2.0 * ebimath.Abs(self.acceleration))
+	acceleration, maxSpeed := self.acceleration, self.maxSpeed
+	if distance > 0 {
+		acceleration *= 1.0 + self.inFactorOff
+		maxSpeed *= 1.0 + self.inFactorOff
+	} else {
+		acceleration *= 1.0 + self.outFactorOff
+		maxSpeed *= 1.0 + self.outFactorOff
+	}
+	predicted := (self.speed * self.speed) / (2.0 * ebimath.Abs(acceleration))
 	target := ebimath.Abs(distance)
 
 	// update speed
 	updateDelta := 1.0 / float64(internal.GetUPS())
 	if predicted < target {
 		if distance >= 0 {
-			self.speed += self.acceleration * updateDelta
-			self.speed = min(self.speed, +self.maxSpeed)
+			self.speed += acceleration * updateDelta
+			self.speed = min(self.speed, +maxSpeed)
 		} else {
-			self.speed -= self.acceleration * updateDelta
-			self.speed = max(self.speed, -self.maxSpeed)
+			self.speed -= acceleration * updateDelta
+			self.speed = max(self.speed, -maxSpeed)
 		}
 	} else {
 		if distance >= 0 {
-			self.speed -= self.acceleration * updateDelta
+			self.speed -= acceleration * updateDelta
 			self.speed = max(self.speed, 0.0)
 		} else {
-			self.speed += self.acceleration * updateDelta
+			self.speed += acceleration * updateDelta
 			self.speed = min(self.speed, 0.0)
 		}
 	}
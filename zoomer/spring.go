@@ -1,6 +1,8 @@
 package zoomer
 
 import (
+	"math"
+
 	ebimath "github.com/edwinsyarief/ebi-math"
 	"github.com/edwinsyarief/mipix/internal"
 )
@@ -9,6 +11,12 @@ var _ Zoomer = (*Spring)(nil)
 
 // Springy zoom. By default, it barely overshoots, but
 // you can set it to be more or less bouncy if you want.
+// For cartoony zooms with a visible overshoot and settle,
+// try lower damping values, like (0.5, 4.0).
+//
+// The zoomer never returns NaN or infinite changes: if the
+// spring ever becomes unstable, its speed is reset and the
+// zoom snaps to the target instead.
 //
 // The implementation is tick-rate independent.
 type Spring struct {
@@ -27,8 +35,9 @@ func (self *Spring) ensureInitialized() {
 	self.initialized = true
 }
 
-// Damping values must be in [0.0, 1.0] range.
-// Power depends on damping, but must be strictly positive.
+// Damping values must be in [0.0, 1.0] range, where lower values
+// lead to more overshoot. Power acts as the spring's stiffness:
+// it depends on damping, but must be strictly positive.
 // Defaults are (0.85, 2.5).
 func (self *Spring) SetParameters(damping, power float64) {
 	if damping < 0.0 || damping > 1.0 {
@@ -92,6 +101,12 @@ func (self *Spring) Update(currentZoom, targetZoom float64) float64 {
 		return targetZoom - currentZoom
 	}
 
+	// safety case, never let the controller receive non-finite values
+	if math.IsNaN(newPosition) || math.IsInf(newPosition, 0) || math.IsNaN(newSpeed) || math.IsInf(newSpeed, 0) {
+		self.speed = 0.0
+		return targetZoom - currentZoom
+	}
+
 	self.speed = newSpeed
 	change := (newPosition - currentZoom)
 